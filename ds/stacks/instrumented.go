@@ -0,0 +1,136 @@
+// Copyright 2023 The extradiable Author. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package stacks
+
+// StackHooks: user callbacks invoked by an instrumented stack.
+// Any hook can be left nil.
+type StackHooks struct {
+	// called after v was pushed. size is the size of the stack after the push
+	OnPush func(v interface{}, size int)
+	// called after v was popped. size is the size of the stack after the pop
+	OnPop func(v interface{}, size int)
+	// called whenever the underlying storage changes its capacity
+	OnResize func(oldCap, newCap int)
+}
+
+// StackStats: metrics collected by an instrumented stack
+type StackStats struct {
+	// number of elements currently stored in the stack
+	Size int
+	// capacity of the underlying storage
+	Capacity int
+	// largest size the stack has ever reached
+	HighWaterMark int
+	// number of successful push operations
+	Pushes int
+	// number of successful pop operations
+	Pops int
+	// number of times the underlying storage grew
+	Grows int
+	// number of times the underlying storage shrinked
+	Shrinks int
+	// total number of slots allocated for the underlying storage,
+	// including the initial allocation
+	Allocations int
+}
+
+// Resizes returns the total number of times the underlying storage changed its capacity
+func (s StackStats) Resizes() int {
+	return s.Grows + s.Shrinks
+}
+
+// IStack: Instrumented Stack.
+// Wraps a DStack, invoking user hooks on push/pop/resize and recording metrics.
+type IStack struct {
+	stack *DStack
+	hooks StackHooks
+	stats StackStats
+}
+
+// Creates a new instrumented stack around a new dynamic stack
+func CreateIStack(hooks StackHooks) *IStack {
+	return Instrument(CreateDStack(), hooks)
+}
+
+// Wraps the dynamic stack s. Elements already stored in s are accounted for
+// in the high-water mark, and the current capacity of s is taken as the initial allocation.
+// s should not be used directly after being wrapped, otherwise the metrics are inaccurate.
+func Instrument(s *DStack, hooks StackHooks) *IStack {
+	return &IStack{
+		stack: s,
+		hooks: hooks,
+		stats: StackStats{
+			HighWaterMark: s.Size(),
+			Allocations:   cap(s.data),
+		},
+	}
+}
+
+// Push value v into the top of the stack.
+// See DStack.Push
+func (s *IStack) Push(v interface{}) {
+	before := cap(s.stack.data)
+	s.stack.Push(v)
+	s.stats.Pushes++
+	if size := s.stack.Size(); size > s.stats.HighWaterMark {
+		s.stats.HighWaterMark = size
+	}
+	s.resized(before)
+	if s.hooks.OnPush != nil {
+		s.hooks.OnPush(v, s.stack.Size())
+	}
+}
+
+// Pop value v from the top of the stack and returns v.
+// See DStack.Pop
+func (s *IStack) Pop() (interface{}, error) {
+	before := cap(s.stack.data)
+	v, err := s.stack.Pop()
+	if err != nil {
+		return v, err
+	}
+	s.stats.Pops++
+	s.resized(before)
+	if s.hooks.OnPop != nil {
+		s.hooks.OnPop(v, s.stack.Size())
+	}
+	return v, nil
+}
+
+// returns true if the stack is currently empty
+func (s *IStack) Empty() bool {
+	return s.stack.Empty()
+}
+
+// Returns the current size of the stack.
+func (s *IStack) Size() int {
+	return s.stack.Size()
+}
+
+// Returns a snapshot of the metrics collected so far
+func (s *IStack) Stats() StackStats {
+	stats := s.stats
+	stats.Size = s.stack.Size()
+	stats.Capacity = cap(s.stack.data)
+	return stats
+}
+
+// updates the resize metrics and invokes the resize hook
+// if the capacity of the underlying storage changed
+func (s *IStack) resized(before int) {
+	after := cap(s.stack.data)
+	if after == before {
+		return
+	}
+	if after > before {
+		s.stats.Grows++
+	} else {
+		s.stats.Shrinks++
+	}
+	s.stats.Allocations += after
+	if s.hooks.OnResize != nil {
+		s.hooks.OnResize(before, after)
+	}
+}
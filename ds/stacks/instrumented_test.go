@@ -0,0 +1,69 @@
+package stacks
+
+import (
+	"testing"
+
+	"github.com/extradiable/golang/ds"
+)
+
+// TestIStackStats:
+// Verify the metrics recorded by an instrumented stack
+func TestIStackStats(t *testing.T) {
+	stack := CreateIStack(StackHooks{})
+	iterations := 100
+	for i := 0; i < iterations; i++ {
+		stack.Push(i)
+	}
+	for i := 0; i < iterations/2; i++ {
+		stack.Pop()
+	}
+	stats := stack.Stats()
+	if stats.HighWaterMark != iterations {
+		t.Fatalf("stats.HighWaterMark: expected value %d, got %d.", iterations, stats.HighWaterMark)
+	}
+	if stats.Size != iterations/2 {
+		t.Fatalf("stats.Size: expected value %d, got %d.", iterations/2, stats.Size)
+	}
+	if stats.Pushes != iterations || stats.Pops != iterations/2 {
+		t.Fatalf("stats: expected %d pushes and %d pops, got %d and %d.", iterations, iterations/2, stats.Pushes, stats.Pops)
+	}
+	if stats.Grows == 0 {
+		t.Fatalf("stats.Grows: expected at least one grow")
+	}
+	if stats.Allocations <= stats.Capacity {
+		t.Fatalf("stats.Allocations: expected more than %d, got %d.", stats.Capacity, stats.Allocations)
+	}
+}
+
+// TestIStackHooks:
+// Verify hooks are invoked on push, pop and resize
+func TestIStackHooks(t *testing.T) {
+	var pushes, pops, resizes int
+	lastCap := 1
+	stack := CreateIStack(StackHooks{
+		OnPush: func(v interface{}, size int) { pushes++ },
+		OnPop:  func(v interface{}, size int) { pops++ },
+		OnResize: func(oldCap, newCap int) {
+			if oldCap != lastCap {
+				t.Fatalf("OnResize: expected old capacity %d, got %d.", lastCap, oldCap)
+			}
+			lastCap = newCap
+			resizes++
+		},
+	})
+	for i := 0; i < 10; i++ {
+		stack.Push(i)
+	}
+	for !stack.Empty() {
+		stack.Pop()
+	}
+	if _, err := stack.Pop(); err != ds.ErrStackUnderflow {
+		t.Fatalf("stack.Pop(): expected '%v' error got '%v'.", ds.ErrStackUnderflow, err)
+	}
+	if pushes != 10 || pops != 10 {
+		t.Fatalf("hooks: expected 10 pushes and 10 pops, got %d and %d.", pushes, pops)
+	}
+	if resizes != stack.Stats().Resizes() {
+		t.Fatalf("OnResize: expected %d calls, got %d.", stack.Stats().Resizes(), resizes)
+	}
+}
@@ -1,33 +1,54 @@
 package graph
 
-func Trace(g Graph, startVertex int) (Path, error) {
-	path, err := g.NewPath(startVertex)
+// walks unused edges greedily from startVertex until a vertex with no unused edges is reached
+// the resulting path uses every edge at most once
+func Trace(g Interface, startVertex int) (Path, error) {
+	path, err := NewPath(g, startVertex)
 	if err != nil {
 		return path, err
 	}
-	visitedEdges := make([]bool, g.Size())
-	nextUnvisitedNeighbor := make([]int, g.Order())
-	currentVertex := startVertex
-	for {
-		neighbors, err := g.GetAdjacencies(currentVertex)
-		if err != nil {
-			return path, err
-		}
-		if nextUnvisitedNeighbor[currentVertex] == len(neighbors) {
-			break
-		}
-		neighbor := neighbors[nextUnvisitedNeighbor[currentVertex]]
-		nextVertex, nextEdge := neighbor.Endpoint, neighbor.Edge
-		nextUnvisitedNeighbor[currentVertex]++
-		if visitedEdges[nextEdge] {
-			continue
-		}
-		visitedEdges[nextEdge] = true
-		path, err = path.Grow(nextVertex, nextEdge)
-		if err != nil {
-			return path, err
+	return path.growSteps(newTracer(g).trace(startVertex))
+}
+
+// keeps track of the edges already walked so that several walks
+// over the same graph never reuse an edge
+type tracer struct {
+	g                     Interface
+	visitedEdges          []bool
+	nextUnvisitedNeighbor []int
+}
+
+func newTracer(g Interface) *tracer {
+	return &tracer{
+		g:                     g,
+		visitedEdges:          make([]bool, g.Size()),
+		nextUnvisitedNeighbor: make([]int, g.Order()),
+	}
+}
+
+// returns true if there are unused edges leaving vertex
+func (t *tracer) hasUnvisited(vertex int) bool {
+	neighbors := adjacencies(t.g, vertex)
+	for t.nextUnvisitedNeighbor[vertex] < len(neighbors) {
+		if !t.visitedEdges[neighbors[t.nextUnvisitedNeighbor[vertex]].Edge] {
+			return true
 		}
-		currentVertex = nextVertex
+		t.nextUnvisitedNeighbor[vertex]++
+	}
+	return false
+}
+
+// walks unused edges from startVertex marking them as used
+// returns the steps of the walk
+func (t *tracer) trace(startVertex int) []Adjacency {
+	steps := make([]Adjacency, 0)
+	currentVertex := startVertex
+	for t.hasUnvisited(currentVertex) {
+		neighbor := adjacencies(t.g, currentVertex)[t.nextUnvisitedNeighbor[currentVertex]]
+		t.nextUnvisitedNeighbor[currentVertex]++
+		t.visitedEdges[neighbor.Edge] = true
+		steps = append(steps, neighbor)
+		currentVertex = neighbor.Endpoint
 	}
-	return path, err
+	return steps
 }
@@ -0,0 +1,71 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+var ErrGoalUnreachable = fmt.Errorf("no goal vertex is reachable from the start vertex")
+
+// a Heuristic estimates the cost of reaching a goal vertex
+// from the vertex holding data
+// A* returns a shortest path as long as the estimate never exceeds the actual cost
+type Heuristic func(data interface{}) float64
+
+// searches a cheapest path from start to any vertex satisfying goal,
+// exploring first the vertexes whose estimated total cost, as given by h, is lowest
+// edge costs are given by w, or by the edge weights if w is nil
+// returns the path found and the number of vertexes expanded during the search
+// the search is aborted and ctx.Err() returned if ctx is done
+func AStar(ctx context.Context, g Interface, start int, goal func(v *Vertex) bool, h Heuristic, w WeightFunc) (Path, int, error) {
+	if err := testVertex(g, start); err != nil {
+		return Path{}, 0, err
+	}
+	costOf := edgeCosts(g, w)
+	if h == nil {
+		h = func(interface{}) float64 { return 0 }
+	}
+	dist, prev := newSearch(g, start)
+	estimate := make([]float64, g.Order())
+	for i := range estimate {
+		estimate[i] = math.NaN()
+	}
+	heuristic := func(v int) float64 {
+		if math.IsNaN(estimate[v]) {
+			estimate[v] = h(vertexAt(g, v).Data)
+		}
+		return estimate[v]
+	}
+	expanded := 0
+	queue := &pqueue{}
+	queue.push(start, heuristic(start))
+	for queue.Len() > 0 {
+		if err := ctx.Err(); err != nil {
+			return Path{}, expanded, err
+		}
+		item := queue.pop()
+		u := item.vertex
+		if item.priority > dist[u]+heuristic(u) {
+			// stale entry, u was reached later through a cheaper path
+			continue
+		}
+		if goal(vertexAt(g, u)) {
+			path, err := buildPath(g, start, u, prev)
+			return path, expanded, err
+		}
+		expanded++
+		for _, o := range adjacencies(g, u) {
+			cost := costOf(o.Edge)
+			if cost < 0 {
+				return Path{}, expanded, ErrNegativeWeight{o.Edge, cost}
+			}
+			if d := dist[u] + cost; d < dist[o.Endpoint] {
+				dist[o.Endpoint] = d
+				prev[o.Endpoint] = Adjacency{u, o.Edge}
+				queue.push(o.Endpoint, d+heuristic(o.Endpoint))
+			}
+		}
+	}
+	return Path{}, expanded, ErrGoalUnreachable
+}
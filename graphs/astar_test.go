@@ -0,0 +1,55 @@
+package graph
+
+import (
+	"context"
+	"math"
+	"testing"
+)
+
+type cell struct {
+	x, y int
+}
+
+// TestAStar:
+// Verify A* finds a shortest path expanding fewer vertexes than an uninformed search
+func TestAStar(t *testing.T) {
+	size := 10
+	g := Graph{}
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			g.AddVertex(cell{x, y})
+		}
+	}
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			if x+1 < size {
+				g.AddEdge(y*size+x, y*size+x+1, nil)
+			}
+			if y+1 < size {
+				g.AddEdge(y*size+x, (y+1)*size+x, nil)
+			}
+		}
+	}
+	target := cell{size - 1, size - 1}
+	goal := func(v *Vertex) bool { return v.Data.(cell) == target }
+	manhattan := func(data interface{}) float64 {
+		c := data.(cell)
+		return math.Abs(float64(target.x-c.x)) + math.Abs(float64(target.y-c.y))
+	}
+	path, expanded, err := AStar(context.Background(), g, 0, goal, manhattan, nil)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if w, _ := path.Weight(); w != float64(2*(size-1)) {
+		t.Fatalf("AStar(): expected a path of weight %d, got %v.", 2*(size-1), w)
+	}
+	_, uninformed, _ := AStar(context.Background(), g, 0, goal, nil, nil)
+	if expanded >= uninformed {
+		t.Fatalf("AStar(): expected less than %d expanded vertexes, got %d.", uninformed, expanded)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := AStar(ctx, g, 0, goal, manhattan, nil); err != context.Canceled {
+		t.Fatalf("AStar(): expected '%v' error got '%v'.", context.Canceled, err)
+	}
+}
@@ -0,0 +1,332 @@
+package graph
+
+import (
+	"fmt"
+	"math"
+)
+
+// returned when a graph is expected to be bipartite but it is not
+// Cycle is a closed path through a cycle with an odd number of edges
+type ErrNotBipartite struct {
+	Cycle Path
+}
+
+func (err ErrNotBipartite) Error() string {
+	return fmt.Sprintf("graph has an odd cycle through vertex: %d", err.Cycle.start)
+}
+
+// returns true if the vertexes of an undirected graph can be split into two sides
+// so that every edge joins vertexes of different sides
+// otherwise false is returned along with an odd cycle proving it
+func IsBipartite(g Interface) (bool, Path) {
+	if _, err := Bipartition(g); err != nil {
+		if notBipartite, ok := err.(ErrNotBipartite); ok {
+			return false, notBipartite.Cycle
+		}
+		return false, Path{}
+	}
+	return true, Path{}
+}
+
+// splits the vertexes of an undirected graph into two sides so that every edge
+// joins vertexes of different sides. returns the side, 0 or 1, of every vertex,
+// or -1 for removed vertexes.
+// the lowest vertex id of each connected component is placed on side 0
+// an ErrNotBipartite is returned if the graph has an odd cycle
+// an ErrDirected is returned for directed graphs
+func Bipartition(g Interface) ([]int, error) {
+	if g.IsDirected() {
+		return nil, ErrDirected
+	}
+	side := make([]int, g.Order())
+	depth := make([]int, g.Order())
+	parent := make([]Adjacency, g.Order())
+	for v := range side {
+		side[v] = -1
+	}
+	for root := 0; root < g.Order(); root++ {
+		if side[root] != -1 || !vertexExists(g, root) {
+			continue
+		}
+		side[root] = 0
+		parent[root] = Adjacency{-1, -1}
+		queue := []int{root}
+		for len(queue) > 0 {
+			u := queue[0]
+			queue = queue[1:]
+			for _, o := range adjacencies(g, u) {
+				v := o.Endpoint
+				if side[v] == -1 {
+					side[v] = 1 - side[u]
+					depth[v] = depth[u] + 1
+					parent[v] = Adjacency{u, o.Edge}
+					queue = append(queue, v)
+				} else if side[v] == side[u] {
+					cycle, err := oddCycle(g, u, v, o.Edge, depth, parent)
+					if err != nil {
+						return nil, err
+					}
+					return nil, ErrNotBipartite{cycle}
+				}
+			}
+		}
+	}
+	return side, nil
+}
+
+// builds the cycle closed by the edge joining u and v in a breadth first tree
+// the cycle goes down the tree from the closest common ancestor of u and v
+// to u, crosses the edge and goes up the tree from v
+func oddCycle(g Interface, u, v, edge int, depth []int, parent []Adjacency) (Path, error) {
+	down := []Adjacency{}
+	up := []Adjacency{}
+	a, b := u, v
+	for a != b {
+		if depth[a] >= depth[b] {
+			down = append(down, Adjacency{a, parent[a].Edge})
+			a = parent[a].Endpoint
+		} else {
+			up = append(up, Adjacency{parent[b].Endpoint, parent[b].Edge})
+			b = parent[b].Endpoint
+		}
+	}
+	path, err := NewPath(g, a)
+	if err != nil {
+		return path, err
+	}
+	steps := append(reverseSteps(down), Adjacency{v, edge})
+	return path.growSteps(append(steps, up...))
+}
+
+// a matching of a bipartite graph
+type Matching struct {
+	// ids of the matched edges
+	Edges []int
+	// Mate[v] is the vertex matched with v, or -1 if v is unmatched
+	Mate []int
+	// sum of the costs of the matched edges
+	Weight float64
+}
+
+// builds a Matching from the edge matched at every vertex
+func newMatching(g Interface, matched []int, w WeightFunc) Matching {
+	m := Matching{Edges: make([]int, 0), Mate: make([]int, g.Order())}
+	costOf := edgeCosts(g, w)
+	for v, e := range matched {
+		m.Mate[v] = -1
+		if e == -1 {
+			continue
+		}
+		m.Mate[v] = edgeAt(g, e).Other(v)
+		if v < m.Mate[v] {
+			m.Edges = append(m.Edges, e)
+			m.Weight += costOf(e)
+		}
+	}
+	return m
+}
+
+// computes a maximum cardinality matching of a bipartite graph
+// using the Hopcroft-Karp algorithm
+// the Weight of the matching is the sum of the edge weights
+// an ErrNotBipartite is returned if the graph has an odd cycle
+func HopcroftKarp(g Interface) (Matching, error) {
+	side, err := Bipartition(g)
+	if err != nil {
+		return Matching{}, err
+	}
+	// matched[v] is the id of the edge matching v, or -1
+	matched := make([]int, g.Order())
+	for v := range matched {
+		matched[v] = -1
+	}
+	mate := func(v int) int {
+		if matched[v] == -1 {
+			return -1
+		}
+		return edgeAt(g, matched[v]).Other(v)
+	}
+	dist := make([]int, g.Order())
+	// the layer of the side 0 vertexes next to the closest free vertexes of side 1
+	shortest := -1
+	// layers the graph from the free vertexes of side 0 along alternating paths,
+	// up to the layer where a free vertex of side 1 is first reached
+	// returns true if a free vertex of side 1 is reached
+	layer := func() bool {
+		queue := []int{}
+		for v := range dist {
+			dist[v] = -1
+			if side[v] == 0 && matched[v] == -1 {
+				dist[v] = 0
+				queue = append(queue, v)
+			}
+		}
+		shortest = -1
+		for len(queue) > 0 {
+			u := queue[0]
+			queue = queue[1:]
+			if shortest != -1 && dist[u] > shortest {
+				break
+			}
+			for _, o := range adjacencies(g, u) {
+				m := mate(o.Endpoint)
+				if m == -1 {
+					shortest = dist[u]
+				} else if dist[m] == -1 {
+					dist[m] = dist[u] + 1
+					queue = append(queue, m)
+				}
+			}
+		}
+		return shortest != -1
+	}
+	// only shortest augmenting paths are followed, so every phase
+	// augments along vertex disjoint paths of the same length
+	var augment func(u int) bool
+	augment = func(u int) bool {
+		for _, o := range adjacencies(g, u) {
+			m := mate(o.Endpoint)
+			if m == -1 && dist[u] == shortest || m != -1 && dist[m] == dist[u]+1 && augment(m) {
+				matched[u] = o.Edge
+				matched[o.Endpoint] = o.Edge
+				return true
+			}
+		}
+		dist[u] = -1
+		return false
+	}
+	for layer() {
+		for v := 0; v < g.Order(); v++ {
+			if side[v] == 0 && matched[v] == -1 {
+				augment(v)
+			}
+		}
+	}
+	return newMatching(g, matched, EdgeWeight), nil
+}
+
+// computes a maximum cardinality matching of a bipartite graph whose total cost
+// is minimum among all maximum cardinality matchings, using the Hungarian algorithm
+// edge costs are given by w, or by the edge weights if w is nil
+// an ErrNotBipartite is returned if the graph has an odd cycle
+func Hungarian(g Interface, w WeightFunc) (Matching, error) {
+	side, err := Bipartition(g)
+	if err != nil {
+		return Matching{}, err
+	}
+	costOf := edgeCosts(g, w)
+	index := make([]int, g.Order())
+	sides := [2][]int{}
+	for v, s := range side {
+		if s == -1 {
+			continue
+		}
+		index[v] = len(sides[s])
+		sides[s] = append(sides[s], v)
+	}
+	n := len(sides[0])
+	if len(sides[1]) > n {
+		n = len(sides[1])
+	}
+	// pairs without an edge cost more than any matching made of edges,
+	// so the cheapest assignment uses as many edges as possible
+	forbidden := 1.0
+	for i := 0; i < g.Size(); i++ {
+		if edgeExists(g, i) {
+			forbidden += 2 * math.Abs(costOf(i))
+		}
+	}
+	cost := make([][]float64, n)
+	via := make([][]int, n)
+	for i := range cost {
+		cost[i] = make([]float64, n)
+		via[i] = make([]int, n)
+		for j := range cost[i] {
+			via[i][j] = -1
+			if i < len(sides[0]) && j < len(sides[1]) {
+				cost[i][j] = forbidden
+			}
+		}
+	}
+	for i, u := range sides[0] {
+		for _, o := range adjacencies(g, u) {
+			j := index[o.Endpoint]
+			if c := costOf(o.Edge); via[i][j] == -1 || c < cost[i][j] {
+				cost[i][j] = c
+				via[i][j] = o.Edge
+			}
+		}
+	}
+	matched := make([]int, g.Order())
+	for v := range matched {
+		matched[v] = -1
+	}
+	for i, j := range assign(cost) {
+		if i < len(sides[0]) && j < len(sides[1]) && via[i][j] != -1 {
+			matched[sides[0][i]] = via[i][j]
+			matched[sides[1][j]] = via[i][j]
+		}
+	}
+	return newMatching(g, matched, w), nil
+}
+
+// solves the assignment problem for the n x n matrix cost
+// returns the column assigned to every row, minimizing the total cost
+func assign(cost [][]float64) []int {
+	n := len(cost)
+	// potentials and matches are 1-based, column 0 is a sentinel
+	u := make([]float64, n+1)
+	v := make([]float64, n+1)
+	row := make([]int, n+1)
+	way := make([]int, n+1)
+	for i := 1; i <= n; i++ {
+		row[0] = i
+		j0 := 0
+		minv := make([]float64, n+1)
+		used := make([]bool, n+1)
+		for j := range minv {
+			minv[j] = math.Inf(1)
+		}
+		for {
+			used[j0] = true
+			i0, delta, j1 := row[j0], math.Inf(1), 0
+			for j := 1; j <= n; j++ {
+				if used[j] {
+					continue
+				}
+				if c := cost[i0-1][j-1] - u[i0] - v[j]; c < minv[j] {
+					minv[j] = c
+					way[j] = j0
+				}
+				if minv[j] < delta {
+					delta = minv[j]
+					j1 = j
+				}
+			}
+			for j := 0; j <= n; j++ {
+				if used[j] {
+					u[row[j]] += delta
+					v[j] -= delta
+				} else {
+					minv[j] -= delta
+				}
+			}
+			j0 = j1
+			if row[j0] == 0 {
+				break
+			}
+		}
+		for j0 != 0 {
+			j1 := way[j0]
+			row[j0] = row[j1]
+			j0 = j1
+		}
+	}
+	columns := make([]int, n)
+	for j := 1; j <= n; j++ {
+		if row[j] != 0 {
+			columns[row[j]-1] = j - 1
+		}
+	}
+	return columns
+}
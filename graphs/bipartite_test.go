@@ -0,0 +1,117 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// TestBipartite:
+// Verify bipartitions and the odd cycles reported
+func TestBipartite(t *testing.T) {
+	g := Graph{}
+	for i := 0; i < 6; i++ {
+		g.AddVertex(i)
+	}
+	g.AddWeightedEdge(0, 3, 4, nil)
+	g.AddWeightedEdge(0, 4, 1, nil)
+	g.AddWeightedEdge(1, 3, 2, nil)
+	g.AddWeightedEdge(1, 5, 9, nil)
+	g.AddWeightedEdge(2, 4, 3, nil)
+	side, err := Bipartition(g)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	for _, e := range g.edges {
+		if side[e.endpoints[0]] == side[e.endpoints[1]] {
+			t.Fatalf("Bipartition(): edge %v joins vertexes of the same side.", e.endpoints)
+		}
+	}
+	g.AddEdge(3, 4, nil)
+	ok, cycle := IsBipartite(g)
+	if ok {
+		t.Fatalf("IsBipartite(): expected false for a graph with a triangle")
+	}
+	if len(cycle.out)%2 != 1 || cycle.GetLastVertex() != cycle.start {
+		t.Fatalf("IsBipartite(): expected an odd cycle, got %v from %d.", cycle.out, cycle.start)
+	}
+}
+
+// TestMatching:
+// Verify maximum cardinality and minimum cost matchings
+func TestMatching(t *testing.T) {
+	g := Graph{}
+	for i := 0; i < 6; i++ {
+		g.AddVertex(i)
+	}
+	g.AddWeightedEdge(0, 3, 4, nil)
+	g.AddWeightedEdge(0, 4, 1, nil)
+	g.AddWeightedEdge(1, 3, 2, nil)
+	g.AddWeightedEdge(1, 5, 9, nil)
+	g.AddWeightedEdge(2, 4, 3, nil)
+	matching, err := HopcroftKarp(g)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if len(matching.Edges) != 3 {
+		t.Fatalf("HopcroftKarp(): expected 3 matched edges, got %v.", matching.Edges)
+	}
+	for _, e := range matching.Edges {
+		vi, vj := g.edges[e].Endpoints()
+		if matching.Mate[vi] != vj || matching.Mate[vj] != vi {
+			t.Fatalf("HopcroftKarp(): edge: %d is not reflected in %v.", e, matching.Mate)
+		}
+	}
+	assignment, err := Hungarian(g, nil)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	// all three workers must be assigned even if 0-4 is the cheapest edge
+	if len(assignment.Edges) != 3 || assignment.Weight != 16 {
+		t.Fatalf("Hungarian(): expected 3 edges of cost 16, got %v of cost %v.", assignment.Edges, assignment.Weight)
+	}
+	g.SetWeight(3, 1)
+	g.SetWeight(0, 1)
+	assignment, _ = Hungarian(g, nil)
+	if assignment.Weight != 5 {
+		t.Fatalf("Hungarian(): expected cost 5, got %v.", assignment.Weight)
+	}
+}
+
+// TestHopcroftKarpRandom:
+// Verify maximum matchings of random bipartite graphs have as many edges as Hungarian finds
+func TestHopcroftKarpRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for round := 0; round < 200; round++ {
+		left, right := 1+r.Intn(12), 1+r.Intn(12)
+		g := NewGraph()
+		for i := 0; i < left+right; i++ {
+			g.AddVertex(i)
+		}
+		for i := 0; i < left; i++ {
+			for j := 0; j < right; j++ {
+				if r.Intn(4) == 0 {
+					g.AddEdge(i, left+j, nil)
+				}
+			}
+		}
+		matching, err := HopcroftKarp(g)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		assignment, err := Hungarian(g, nil)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		if len(matching.Edges) != len(assignment.Edges) {
+			t.Fatalf("HopcroftKarp(): expected %d matched edges, got %d.", len(assignment.Edges), len(matching.Edges))
+		}
+		seen := make([]bool, g.Order())
+		for _, e := range matching.Edges {
+			vi, vj := g.edges[e].Endpoints()
+			if seen[vi] || seen[vj] {
+				t.Fatalf("HopcroftKarp(): vertex matched twice in %v.", matching.Edges)
+			}
+			seen[vi], seen[vj] = true, true
+		}
+	}
+}
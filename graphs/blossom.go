@@ -0,0 +1,551 @@
+package graph
+
+// an edge of the general graph matched by maxWeightMatching
+type matchingEdge struct {
+	i, j   int
+	weight float64
+}
+
+// the state of Edmonds' blossom algorithm for maximum weight matching
+// vertexes are 0..n-1 and blossoms n..2n-1. every edge k has two endpoints,
+// 2k is its first vertex and 2k+1 its second one, so p^1 is the other end of p
+type blossomMatcher struct {
+	n     int
+	edges []matchingEdge
+	// endpoint[p] is the vertex of the endpoint p
+	endpoint []int
+	// neighbors[v] holds the endpoints of the edges of v on their other side
+	neighbors [][]int
+	// mate[v] is the endpoint of the matched edge of v reaching its mate, or -1
+	mate []int
+	// label of every top level blossom: 0 unlabeled, 1 S (outer), 2 T (inner),
+	// 5 temporarily while scanning for a blossom
+	label []int
+	// the endpoint through which a labeled blossom got its label, or -1
+	labelEnd []int
+	// the top level blossom holding every vertex
+	inBlossom []int
+	// the blossom holding each blossom or vertex, -1 at the top level
+	parent []int
+	// the sub-blossoms of every blossom in cyclic order, starting at its base
+	children [][]int
+	// the base vertex of every blossom, -1 for unused blossoms
+	base []int
+	// endpoints[b][i] joins children[b][i] with children[b][i+1]
+	endpoints [][]int
+	// the least slack edge from every vertex or S blossom to another S blossom, or -1
+	bestEdge []int
+	// the least slack edges from a non-trivial S blossom to each other S blossom
+	blossomBestEdges [][]int
+	unused           []int
+	// dual variables of vertexes and blossoms
+	dual []float64
+	// true for the edges known to have zero slack
+	allowed []bool
+	queue   []int
+}
+
+// computes a maximum weight matching among the maximum cardinality matchings
+// of the general graph with n vertexes and the given edges, using Edmonds' blossom algorithm
+// in O(n^3) time, see Galil, "Efficient algorithms for finding maximum matching in graphs"
+// returns the mate of every vertex, or -1 for unmatched vertexes
+func maxWeightMatching(n int, edges []matchingEdge) []int {
+	m := &blossomMatcher{
+		n:                n,
+		edges:            edges,
+		endpoint:         make([]int, 2*len(edges)),
+		neighbors:        make([][]int, n),
+		mate:             make([]int, n),
+		label:            make([]int, 2*n),
+		labelEnd:         make([]int, 2*n),
+		inBlossom:        make([]int, n),
+		parent:           make([]int, 2*n),
+		children:         make([][]int, 2*n),
+		base:             make([]int, 2*n),
+		endpoints:        make([][]int, 2*n),
+		bestEdge:         make([]int, 2*n),
+		blossomBestEdges: make([][]int, 2*n),
+		unused:           make([]int, 0, n),
+		dual:             make([]float64, 2*n),
+		allowed:          make([]bool, len(edges)),
+	}
+	maxWeight := 0.0
+	for k, e := range edges {
+		m.endpoint[2*k], m.endpoint[2*k+1] = e.i, e.j
+		m.neighbors[e.i] = append(m.neighbors[e.i], 2*k+1)
+		m.neighbors[e.j] = append(m.neighbors[e.j], 2*k)
+		if e.weight > maxWeight {
+			maxWeight = e.weight
+		}
+	}
+	for v := 0; v < 2*n; v++ {
+		m.labelEnd[v] = -1
+		m.parent[v] = -1
+		m.base[v] = -1
+		if v < n {
+			m.mate[v] = -1
+			m.inBlossom[v] = v
+			m.base[v] = v
+			m.dual[v] = maxWeight
+		} else {
+			m.unused = append(m.unused, v)
+		}
+	}
+	// every stage augments the matching by one edge
+	for stage := 0; stage < n; stage++ {
+		if !m.stage() {
+			break
+		}
+		// blossoms with a zero dual are not needed anymore
+		for b := n; b < 2*n; b++ {
+			if m.parent[b] == -1 && m.base[b] >= 0 && m.label[b] == 1 && m.dual[b] == 0 {
+				m.expand(b, true)
+			}
+		}
+	}
+	mates := make([]int, n)
+	for v := range mates {
+		mates[v] = -1
+		if m.mate[v] >= 0 {
+			mates[v] = m.endpoint[m.mate[v]]
+		}
+	}
+	return mates
+}
+
+// returns the slack of the edge k, which is 0 for edges that can be added to the matching
+func (m *blossomMatcher) slack(k int) float64 {
+	e := m.edges[k]
+	return m.dual[e.i] + m.dual[e.j] - 2*e.weight
+}
+
+// appends the vertexes inside the blossom b to leaves
+func (m *blossomMatcher) leaves(b int, leaves []int) []int {
+	if b < m.n {
+		return append(leaves, b)
+	}
+	for _, t := range m.children[b] {
+		leaves = m.leaves(t, leaves)
+	}
+	return leaves
+}
+
+// searches an augmenting path and augments the matching along it
+// returns false if there is none
+func (m *blossomMatcher) stage() bool {
+	for i := range m.label {
+		m.label[i] = 0
+		m.bestEdge[i] = -1
+		if i >= m.n {
+			m.blossomBestEdges[i] = nil
+		}
+	}
+	for k := range m.allowed {
+		m.allowed[k] = false
+	}
+	m.queue = m.queue[:0]
+	for v := 0; v < m.n; v++ {
+		if m.mate[v] == -1 && m.label[m.inBlossom[v]] == 0 {
+			m.assignLabel(v, 1, -1)
+		}
+	}
+	for {
+		for len(m.queue) > 0 {
+			v := m.queue[len(m.queue)-1]
+			m.queue = m.queue[:len(m.queue)-1]
+			for _, p := range m.neighbors[v] {
+				k, w := p/2, m.endpoint[p]
+				if m.inBlossom[v] == m.inBlossom[w] {
+					continue
+				}
+				slack := 0.0
+				if !m.allowed[k] {
+					if slack = m.slack(k); slack <= 0 {
+						m.allowed[k] = true
+					}
+				}
+				switch {
+				case m.allowed[k] && m.label[m.inBlossom[w]] == 0:
+					m.assignLabel(w, 2, p^1)
+				case m.allowed[k] && m.label[m.inBlossom[w]] == 1:
+					if base := m.scanBlossom(v, w); base >= 0 {
+						m.addBlossom(base, k)
+					} else {
+						m.augment(k)
+						return true
+					}
+				case m.allowed[k] && m.label[w] == 0:
+					// w is inside a T blossom but was not reached yet
+					m.label[w] = 2
+					m.labelEnd[w] = p ^ 1
+				case !m.allowed[k] && m.label[m.inBlossom[w]] == 1:
+					if b := m.inBlossom[v]; m.bestEdge[b] == -1 || slack < m.slack(m.bestEdge[b]) {
+						m.bestEdge[b] = k
+					}
+				case !m.allowed[k] && m.label[w] == 0:
+					if m.bestEdge[w] == -1 || slack < m.slack(m.bestEdge[w]) {
+						m.bestEdge[w] = k
+					}
+				}
+			}
+		}
+		// no tight edge is left, the duals are changed to make one
+		deltaType, delta, deltaEdge, deltaBlossom := -1, 0.0, -1, -1
+		for v := 0; v < m.n; v++ {
+			if m.label[m.inBlossom[v]] == 0 && m.bestEdge[v] != -1 {
+				if d := m.slack(m.bestEdge[v]); deltaType == -1 || d < delta {
+					deltaType, delta, deltaEdge = 2, d, m.bestEdge[v]
+				}
+			}
+		}
+		for b := 0; b < 2*m.n; b++ {
+			if m.parent[b] == -1 && m.label[b] == 1 && m.bestEdge[b] != -1 {
+				if d := m.slack(m.bestEdge[b]) / 2; deltaType == -1 || d < delta {
+					deltaType, delta, deltaEdge = 3, d, m.bestEdge[b]
+				}
+			}
+		}
+		for b := m.n; b < 2*m.n; b++ {
+			if m.base[b] >= 0 && m.parent[b] == -1 && m.label[b] == 2 && (deltaType == -1 || m.dual[b] < delta) {
+				deltaType, delta, deltaBlossom = 4, m.dual[b], b
+			}
+		}
+		if deltaType == -1 {
+			// no further improvement is possible, the duals are made optimal
+			deltaType, delta = 1, m.dual[0]
+			for v := 1; v < m.n; v++ {
+				if m.dual[v] < delta {
+					delta = m.dual[v]
+				}
+			}
+			if delta < 0 {
+				delta = 0
+			}
+		}
+		for v := 0; v < m.n; v++ {
+			switch m.label[m.inBlossom[v]] {
+			case 1:
+				m.dual[v] -= delta
+			case 2:
+				m.dual[v] += delta
+			}
+		}
+		for b := m.n; b < 2*m.n; b++ {
+			if m.base[b] >= 0 && m.parent[b] == -1 {
+				switch m.label[b] {
+				case 1:
+					m.dual[b] += delta
+				case 2:
+					m.dual[b] -= delta
+				}
+			}
+		}
+		switch deltaType {
+		case 1:
+			return false
+		case 2, 3:
+			m.allowed[deltaEdge] = true
+			i, j := m.edges[deltaEdge].i, m.edges[deltaEdge].j
+			if m.label[m.inBlossom[i]] == 0 {
+				i = j
+			}
+			m.queue = append(m.queue, i)
+		case 4:
+			m.expand(deltaBlossom, false)
+		}
+	}
+}
+
+// labels the top level blossom of w with t, reached through the endpoint p
+// the mate of the base of a T blossom gets an S label
+func (m *blossomMatcher) assignLabel(w, t, p int) {
+	for {
+		b := m.inBlossom[w]
+		m.label[w], m.label[b] = t, t
+		m.labelEnd[w], m.labelEnd[b] = p, p
+		m.bestEdge[w], m.bestEdge[b] = -1, -1
+		if t == 1 {
+			m.queue = m.leaves(b, m.queue)
+			return
+		}
+		mate := m.mate[m.base[b]]
+		w, t, p = m.endpoint[mate], 1, mate^1
+	}
+}
+
+// follows the labels back from v and w to find the base of the blossom they close
+// returns -1 if they lead to different roots, so the edge joining them augments the matching
+func (m *blossomMatcher) scanBlossom(v, w int) int {
+	path := make([]int, 0)
+	base := -1
+	for v != -1 || w != -1 {
+		b := m.inBlossom[v]
+		if m.label[b]&4 != 0 {
+			base = m.base[b]
+			break
+		}
+		path = append(path, b)
+		m.label[b] = 5
+		if m.labelEnd[b] == -1 {
+			// the root of the alternating tree
+			v = -1
+		} else {
+			v = m.endpoint[m.labelEnd[b]]
+			b = m.inBlossom[v]
+			v = m.endpoint[m.labelEnd[b]]
+		}
+		if w != -1 {
+			v, w = w, v
+		}
+	}
+	for _, b := range path {
+		m.label[b] = 1
+	}
+	return base
+}
+
+// makes a new S blossom with the given base from the cycle closed by the edge k
+func (m *blossomMatcher) addBlossom(base, k int) {
+	v, w := m.edges[k].i, m.edges[k].j
+	bb, bv, bw := m.inBlossom[base], m.inBlossom[v], m.inBlossom[w]
+	b := m.unused[len(m.unused)-1]
+	m.unused = m.unused[:len(m.unused)-1]
+	m.base[b] = base
+	m.parent[b] = -1
+	m.parent[bb] = b
+	path, endpoints := make([]int, 0), make([]int, 0)
+	for bv != bb {
+		m.parent[bv] = b
+		path = append(path, bv)
+		endpoints = append(endpoints, m.labelEnd[bv])
+		v = m.endpoint[m.labelEnd[bv]]
+		bv = m.inBlossom[v]
+	}
+	path = append(path, bb)
+	reverse(path)
+	reverse(endpoints)
+	endpoints = append(endpoints, 2*k)
+	for bw != bb {
+		m.parent[bw] = b
+		path = append(path, bw)
+		endpoints = append(endpoints, m.labelEnd[bw]^1)
+		w = m.endpoint[m.labelEnd[bw]]
+		bw = m.inBlossom[w]
+	}
+	m.children[b], m.endpoints[b] = path, endpoints
+	m.label[b] = 1
+	m.labelEnd[b] = m.labelEnd[bb]
+	m.dual[b] = 0
+	for _, leaf := range m.leaves(b, nil) {
+		if m.label[m.inBlossom[leaf]] == 2 {
+			// T vertexes become S vertexes inside the new blossom
+			m.queue = append(m.queue, leaf)
+		}
+		m.inBlossom[leaf] = b
+	}
+	// the least slack edges to other S blossoms are merged from the sub-blossoms
+	bestTo := make([]int, 2*m.n)
+	for i := range bestTo {
+		bestTo[i] = -1
+	}
+	for _, sub := range path {
+		var candidates []int
+		if m.blossomBestEdges[sub] == nil {
+			for _, leaf := range m.leaves(sub, nil) {
+				for _, p := range m.neighbors[leaf] {
+					candidates = append(candidates, p/2)
+				}
+			}
+		} else {
+			candidates = m.blossomBestEdges[sub]
+		}
+		for _, e := range candidates {
+			i, j := m.edges[e].i, m.edges[e].j
+			if m.inBlossom[j] == b {
+				i, j = j, i
+			}
+			bj := m.inBlossom[j]
+			if bj != b && m.label[bj] == 1 && (bestTo[bj] == -1 || m.slack(e) < m.slack(bestTo[bj])) {
+				bestTo[bj] = e
+			}
+		}
+		m.blossomBestEdges[sub] = nil
+		m.bestEdge[sub] = -1
+	}
+	m.blossomBestEdges[b] = make([]int, 0)
+	m.bestEdge[b] = -1
+	for _, e := range bestTo {
+		if e == -1 {
+			continue
+		}
+		m.blossomBestEdges[b] = append(m.blossomBestEdges[b], e)
+		if m.bestEdge[b] == -1 || m.slack(e) < m.slack(m.bestEdge[b]) {
+			m.bestEdge[b] = e
+		}
+	}
+}
+
+// splits the blossom b into its sub-blossoms
+// at the end of a stage, sub-blossoms with a zero dual are expanded too.
+// during a stage b is a T blossom, whose sub-blossoms on the even side
+// of the path from its entry to its base get labels
+func (m *blossomMatcher) expand(b int, endStage bool) {
+	for _, s := range m.children[b] {
+		m.parent[s] = -1
+		switch {
+		case s < m.n:
+			m.inBlossom[s] = s
+		case endStage && m.dual[s] == 0:
+			m.expand(s, endStage)
+		default:
+			for _, leaf := range m.leaves(s, nil) {
+				m.inBlossom[leaf] = s
+			}
+		}
+	}
+	if !endStage && m.label[b] == 2 {
+		children, endpoints := m.children[b], m.endpoints[b]
+		entry := m.inBlossom[m.endpoint[m.labelEnd[b]^1]]
+		j := index(children, entry)
+		step, trick := -1, 1
+		if j&1 != 0 {
+			j -= len(children)
+			step, trick = 1, 0
+		}
+		at := func(i int) int {
+			return (i + len(children)) % len(children)
+		}
+		p := m.labelEnd[b]
+		for j != 0 {
+			// relabels the T sub-blossoms on the path to the base
+			m.label[m.endpoint[p^1]] = 0
+			m.label[m.endpoint[endpoints[at(j-trick)]^trick^1]] = 0
+			m.assignLabel(m.endpoint[p^1], 2, p)
+			m.allowed[endpoints[at(j-trick)]/2] = true
+			j += step
+			p = endpoints[at(j-trick)] ^ trick
+			m.allowed[p/2] = true
+			j += step
+		}
+		bv := children[at(j)]
+		m.label[m.endpoint[p^1]], m.label[bv] = 2, 2
+		m.labelEnd[m.endpoint[p^1]], m.labelEnd[bv] = p, p
+		m.bestEdge[bv] = -1
+		j += step
+		for children[at(j)] != entry {
+			// sub-blossoms off the path keep no label unless one of their vertexes
+			// was reached from outside of b
+			bv = children[at(j)]
+			if m.label[bv] == 1 {
+				j += step
+				continue
+			}
+			reached := -1
+			for _, leaf := range m.leaves(bv, nil) {
+				if m.label[leaf] != 0 {
+					reached = leaf
+					break
+				}
+			}
+			if reached != -1 {
+				m.label[reached] = 0
+				m.label[m.endpoint[m.mate[m.base[bv]]]] = 0
+				m.assignLabel(reached, 2, m.labelEnd[reached])
+			}
+			j += step
+		}
+	}
+	m.label[b], m.labelEnd[b] = -1, -1
+	m.children[b], m.endpoints[b] = nil, nil
+	m.base[b] = -1
+	m.blossomBestEdges[b] = nil
+	m.bestEdge[b] = -1
+	m.unused = append(m.unused, b)
+}
+
+// swaps the matched and unmatched edges on the path from the vertex v to the base
+// of the blossom b, so that v becomes its base
+func (m *blossomMatcher) augmentBlossom(b, v int) {
+	t := v
+	for m.parent[t] != b {
+		t = m.parent[t]
+	}
+	if t >= m.n {
+		m.augmentBlossom(t, v)
+	}
+	children, endpoints := m.children[b], m.endpoints[b]
+	at := func(i int) int {
+		return (i + len(children)) % len(children)
+	}
+	i := index(children, t)
+	j := i
+	step, trick := -1, 1
+	if i&1 != 0 {
+		j -= len(children)
+		step, trick = 1, 0
+	}
+	for j != 0 {
+		j += step
+		t = children[at(j)]
+		p := endpoints[at(j-trick)] ^ trick
+		if t >= m.n {
+			m.augmentBlossom(t, m.endpoint[p])
+		}
+		j += step
+		t = children[at(j)]
+		if t >= m.n {
+			m.augmentBlossom(t, m.endpoint[p^1])
+		}
+		m.mate[m.endpoint[p]] = p ^ 1
+		m.mate[m.endpoint[p^1]] = p
+	}
+	// the children are rotated so that the new base comes first
+	m.children[b] = append(append(make([]int, 0, len(children)), children[i:]...), children[:i]...)
+	m.endpoints[b] = append(append(make([]int, 0, len(endpoints)), endpoints[i:]...), endpoints[:i]...)
+	m.base[b] = m.base[m.children[b][0]]
+}
+
+// augments the matching along the path through the edge k
+// joining two S vertexes of different alternating trees
+func (m *blossomMatcher) augment(k int) {
+	for _, side := range [2][2]int{{m.edges[k].i, 2*k + 1}, {m.edges[k].j, 2 * k}} {
+		s, p := side[0], side[1]
+		for {
+			bs := m.inBlossom[s]
+			if bs >= m.n {
+				m.augmentBlossom(bs, s)
+			}
+			m.mate[s] = p
+			if m.labelEnd[bs] == -1 {
+				// the root of the tree was reached
+				break
+			}
+			t := m.endpoint[m.labelEnd[bs]]
+			bt := m.inBlossom[t]
+			s = m.endpoint[m.labelEnd[bt]]
+			j := m.endpoint[m.labelEnd[bt]^1]
+			if bt >= m.n {
+				m.augmentBlossom(bt, j)
+			}
+			m.mate[j] = m.labelEnd[bt]
+			p = m.labelEnd[bt] ^ 1
+		}
+	}
+}
+
+// reverses a in place
+func reverse(a []int) {
+	for i, j := 0, len(a)-1; i < j; i, j = i+1, j-1 {
+		a[i], a[j] = a[j], a[i]
+	}
+}
+
+// returns the position of x in a, or -1 if it is not there
+func index(a []int, x int) int {
+	for i, y := range a {
+		if y == x {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,170 @@
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// a PayloadCodec converts the Data of vertexes and edges to and from text
+// so that it can be stored by the serialization formats of this package
+type PayloadCodec interface {
+	Encode(data interface{}) (string, error)
+	Decode(text string) (interface{}, error)
+}
+
+// the codecs used for vertex and edge Data
+// a nil codec means StringCodec
+type Codecs struct {
+	Vertex PayloadCodec
+	Edge   PayloadCodec
+}
+
+func (c Codecs) vertex() PayloadCodec {
+	if c.Vertex == nil {
+		return StringCodec{}
+	}
+	return c.Vertex
+}
+
+func (c Codecs) edge() PayloadCodec {
+	if c.Edge == nil {
+		return StringCodec{}
+	}
+	return c.Edge
+}
+
+// encodes Data with fmt.Sprint and decodes it as a string
+// nil Data is encoded as the empty string, and the empty string is decoded as nil
+type StringCodec struct{}
+
+func (StringCodec) Encode(data interface{}) (string, error) {
+	if data == nil {
+		return "", nil
+	}
+	return fmt.Sprint(data), nil
+}
+
+func (StringCodec) Decode(text string) (interface{}, error) {
+	if text == "" {
+		return nil, nil
+	}
+	return text, nil
+}
+
+// encodes Data as JSON
+// values are decoded into the types used by encoding/json for interface{} values,
+// unless New is given, in which case they are decoded into the value pointed by New()
+// and that pointer is returned
+type JSONCodec struct {
+	New func() interface{}
+}
+
+func (JSONCodec) Encode(data interface{}) (string, error) {
+	text, err := json.Marshal(data)
+	return string(text), err
+}
+
+func (c JSONCodec) Decode(text string) (interface{}, error) {
+	if c.New != nil {
+		data := c.New()
+		return data, json.Unmarshal([]byte(text), data)
+	}
+	var data interface{}
+	err := json.Unmarshal([]byte(text), &data)
+	return data, err
+}
+
+// ids read by the serialization formats must be smaller than maxIDRatio times
+// the number of elements read plus maxIDSlack. ids missing from the input become
+// removed placeholders, so this keeps a single large id from allocating billions of them
+const maxIDRatio, maxIDSlack = 4, 1024
+
+// returns an error if bound ids are too many for count elements read, see maxIDRatio
+func testIDBound(kind string, bound, count int) error {
+	if bound > maxIDRatio*count+maxIDSlack {
+		return fmt.Errorf("%s id: %d is too large for the %d elements read", kind, bound-1, count)
+	}
+	return nil
+}
+
+// rebuilds a graph from decoded vertexes and edges keeping their ids
+// ids missing from the input are restored as removed vertexes and edges
+type graphBuilder struct {
+	g        Graph
+	vertexes map[int]interface{}
+	edges    map[int]Edge
+}
+
+func newGraphBuilder(directed bool) *graphBuilder {
+	b := &graphBuilder{
+		g:        NewGraph(),
+		vertexes: make(map[int]interface{}),
+		edges:    make(map[int]Edge),
+	}
+	b.g.directed = directed
+	return b
+}
+
+func (b *graphBuilder) vertex(id int, data interface{}) error {
+	if id < 0 {
+		return ErrOutOfBounds{"vertex", id}
+	}
+	if _, ok := b.vertexes[id]; ok {
+		return fmt.Errorf("duplicated vertex id: %d", id)
+	}
+	b.vertexes[id] = data
+	return nil
+}
+
+func (b *graphBuilder) edge(id, vi, vj int, weight float64, data interface{}) error {
+	if id < 0 {
+		return ErrOutOfBounds{"edge", id}
+	}
+	if _, ok := b.edges[id]; ok {
+		return fmt.Errorf("duplicated edge id: %d", id)
+	}
+	b.edges[id] = Edge{id: id, endpoints: [2]int{vi, vj}, weight: weight, Data: data}
+	return nil
+}
+
+// returns the graph holding every vertex and edge given so far
+func (b *graphBuilder) build() (Graph, error) {
+	order, size := 0, 0
+	for id := range b.vertexes {
+		if id >= order {
+			order = id + 1
+		}
+	}
+	for id := range b.edges {
+		if id >= size {
+			size = id + 1
+		}
+	}
+	if err := testIDBound("vertex", order, len(b.vertexes)); err != nil {
+		return Graph{}, err
+	}
+	if err := testIDBound("edge", size, len(b.edges)); err != nil {
+		return Graph{}, err
+	}
+	g := b.g
+	for id := 0; id < order; id++ {
+		data, ok := b.vertexes[id]
+		g.AddVertex(data)
+		if !ok {
+			g.vertexes[id].removed = true
+		}
+	}
+	for id := 0; id < size; id++ {
+		e, ok := b.edges[id]
+		if !ok {
+			g.edges = append(g.edges, Edge{id: id, endpoints: [2]int{-1, -1}, removed: true})
+			continue
+		}
+		vi, vj := e.endpoints[0], e.endpoints[1]
+		if err := g.testVertex(vi, vj); err != nil {
+			return Graph{}, fmt.Errorf("edge: %d: %v", id, err)
+		}
+		g.AddWeightedEdge(vi, vj, e.weight, e.Data)
+	}
+	return g, nil
+}
@@ -0,0 +1,146 @@
+package graph
+
+import "fmt"
+
+// partitions the vertexes into connected components
+// edge directions are ignored, so weakly connected components are returned for directed graphs
+// returns the component id of every vertex, ids are 0-based, and the number of components
+// removed vertexes belong to no component and get the id -1
+func ConnectedComponents(g Interface) ([]int, int) {
+	components := make([]int, g.Order())
+	for i := range components {
+		components[i] = -1
+	}
+	count := 0
+	for root := 0; root < g.Order(); root++ {
+		if components[root] != -1 || !vertexExists(g, root) {
+			continue
+		}
+		components[root] = count
+		pending := []int{root}
+		for len(pending) > 0 {
+			v := pending[len(pending)-1]
+			pending = pending[:len(pending)-1]
+			for _, adjacencies := range [][]Adjacency{adjacencies(g, v), inAdjacencies(g, v)} {
+				for _, o := range adjacencies {
+					if components[o.Endpoint] == -1 {
+						components[o.Endpoint] = count
+						pending = append(pending, o.Endpoint)
+					}
+				}
+			}
+		}
+		count++
+	}
+	return components, count
+}
+
+// partitions the vertexes into strongly connected components using Tarjan's algorithm
+// for undirected graphs this is the same as ConnectedComponents
+// returns the component id of every vertex, -1 for removed vertexes, and the number of components
+// components are numbered in reverse topological order of the condensation:
+// edges between components always go from a higher id to a lower id
+func StronglyConnectedComponents(g Interface) ([]int, int) {
+	if !g.IsDirected() {
+		return ConnectedComponents(g)
+	}
+	components := make([]int, g.Order())
+	for v := range components {
+		components[v] = -1
+	}
+	index := make([]int, g.Order())
+	low := make([]int, g.Order())
+	parent := make([]int, g.Order())
+	onStack := make([]bool, g.Order())
+	stack := make([]int, 0)
+	count, time := 0, 0
+	reach := func(from, to, edge int) error {
+		if onStack[to] && index[to] < low[from] {
+			low[from] = index[to]
+		}
+		return nil
+	}
+	DFSAll(g, Visitor{
+		DiscoverVertex: func(v, depth int) error {
+			index[v], low[v] = time, time
+			time++
+			if depth == 0 {
+				parent[v] = -1
+			}
+			stack = append(stack, v)
+			onStack[v] = true
+			return nil
+		},
+		TreeEdge: func(from, to, edge int) error {
+			parent[to] = from
+			return nil
+		},
+		BackEdge:  reach,
+		CrossEdge: reach,
+		FinishVertex: func(v int) error {
+			if low[v] == index[v] {
+				for {
+					w := stack[len(stack)-1]
+					stack = stack[:len(stack)-1]
+					onStack[w] = false
+					components[w] = count
+					if w == v {
+						break
+					}
+				}
+				count++
+			}
+			if p := parent[v]; p != -1 && low[v] < low[p] {
+				low[p] = low[v]
+			}
+			return nil
+		},
+	})
+	return components, count
+}
+
+// builds the condensation of g given a partition of its vertexes into count components,
+// as returned by ConnectedComponents or StronglyConnectedComponents
+// the result is a directed graph with a vertex per component, whose Data is the []int
+// of vertexes in it, and an edge per pair of components joined by edges of g,
+// whose Data is the []int of those edges. edges inside a component are dropped
+func Condensation(g Interface, components []int, count int) (Graph, error) {
+	c := NewDigraph()
+	if len(components) != g.Order() {
+		return c, fmt.Errorf("condensation: %d component ids given for %d vertexes", len(components), g.Order())
+	}
+	members := make([][]int, count)
+	for v, id := range components {
+		if id == -1 && !vertexExists(g, v) {
+			continue
+		}
+		if id < 0 || id >= count {
+			return c, ErrOutOfBounds{"component", id}
+		}
+		members[id] = append(members[id], v)
+	}
+	for _, m := range members {
+		c.AddVertex(m)
+	}
+	joined := make(map[[2]int]int)
+	for e := 0; e < g.Size(); e++ {
+		vi, vj, err := g.Endpoints(e)
+		if err != nil {
+			continue
+		}
+		from, to := components[vi], components[vj]
+		if from == to {
+			continue
+		}
+		if !g.IsDirected() && from > to {
+			from, to = to, from
+		}
+		id, ok := joined[[2]int{from, to}]
+		if !ok {
+			id, _ = c.AddEdge(from, to, []int{})
+			joined[[2]int{from, to}] = id
+		}
+		c.edges[id].Data = append(c.edges[id].Data.([]int), e)
+	}
+	return c, nil
+}
@@ -0,0 +1,62 @@
+package graph
+
+import "testing"
+
+// TestConnectedComponents:
+// Verify vertexes are partitioned into connected components
+func TestConnectedComponents(t *testing.T) {
+	g := Graph{}
+	for i := 0; i < 6; i++ {
+		g.AddVertex(i)
+	}
+	g.AddEdge(0, 1, nil)
+	g.AddEdge(2, 1, nil)
+	g.AddEdge(3, 4, nil)
+	components, count := ConnectedComponents(g)
+	if count != 3 {
+		t.Fatalf("ConnectedComponents(): expected 3 components, got %d.", count)
+	}
+	if components[0] != components[2] || components[3] != components[4] || components[0] == components[3] || components[5] == components[0] {
+		t.Fatalf("ConnectedComponents(): unexpected partition %v.", components)
+	}
+}
+
+// TestStronglyConnectedComponents:
+// Verify Tarjan's algorithm and the condensation graph
+func TestStronglyConnectedComponents(t *testing.T) {
+	g := NewDigraph()
+	for i := 0; i < 6; i++ {
+		g.AddVertex(i)
+	}
+	// cycles 0 1 2 and 3 4 joined by 2->3 and 1->4, vertex 5 reached from 4
+	g.AddEdge(0, 1, nil)
+	g.AddEdge(1, 2, nil)
+	g.AddEdge(2, 0, nil)
+	g.AddEdge(3, 4, nil)
+	g.AddEdge(4, 3, nil)
+	g.AddEdge(2, 3, nil)
+	g.AddEdge(1, 4, nil)
+	g.AddEdge(4, 5, nil)
+	components, count := StronglyConnectedComponents(g)
+	if count != 3 {
+		t.Fatalf("StronglyConnectedComponents(): expected 3 components, got %d: %v.", count, components)
+	}
+	if components[0] != components[1] || components[1] != components[2] || components[3] != components[4] || components[2] == components[3] {
+		t.Fatalf("StronglyConnectedComponents(): unexpected partition %v.", components)
+	}
+	c, err := Condensation(g, components, count)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if c.Order() != 3 || c.Size() != 2 {
+		t.Fatalf("Condensation(): expected 3 vertexes and 2 edges, got %d and %d.", c.Order(), c.Size())
+	}
+	for _, e := range c.edges {
+		if e.endpoints[0] <= e.endpoints[1] {
+			t.Fatalf("Condensation(): expected edges from higher to lower ids, got %v.", e.endpoints)
+		}
+	}
+	if joined := c.edges[0].Data.([]int); len(joined) != 2 {
+		t.Fatalf("Condensation(): expected 2 edges joining the cycles, got %v.", joined)
+	}
+}
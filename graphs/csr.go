@@ -0,0 +1,309 @@
+package graph
+
+// a read-only graph stored in compressed sparse rows:
+// the adjacency lists of all the vertexes are packed in a single array
+// and every vertex refers to its own range of that array
+// vertexes and edges are stored field by field instead of as Vertex and Edge values,
+// GetVertex and GetEdge build a new view of them on every call
+// the ids, payloads and adjacency order of the source graph are kept,
+// so algorithms return the same results on both
+// a CSRGraph is a handle to its immutable arrays, copying it is cheap
+type CSRGraph struct {
+	*csr
+}
+
+// the arrays of a CSRGraph
+type csr struct {
+	directed bool
+	// the adjacencies of V[i] are out[offsets[i]:offsets[i+1]]
+	offsets []int
+	out     []Adjacency
+	// same as offsets and out for the edges entering each vertex, only used by directed graphs
+	inOffsets []int
+	in        []Adjacency
+	// payloads of the vertexes, nil if all of them are nil
+	vertexData []interface{}
+	// true for removed vertex ids, nil if none was removed
+	vertexRemoved []bool
+	vertexCount   int
+	endpoints     [][2]int
+	weights       []float64
+	// payloads of the edges, nil if all of them are nil
+	edgeData []interface{}
+	// true for removed edge ids, nil if none was removed
+	edgeRemoved []bool
+	edgeCount   int
+}
+
+// returns a compressed sparse row copy of g
+func NewCSRGraph(g Interface) CSRGraph {
+	c := CSRGraph{&csr{
+		directed:  g.IsDirected(),
+		endpoints: make([][2]int, g.Size()),
+		weights:   make([]float64, g.Size()),
+	}}
+	c.offsets, c.out = packAdjacencies(g, adjacencies)
+	if c.directed {
+		c.inOffsets, c.in = packAdjacencies(g, inAdjacencies)
+	}
+	for i := 0; i < g.Order(); i++ {
+		data, err := g.VertexData(i)
+		if err != nil {
+			if c.vertexRemoved == nil {
+				c.vertexRemoved = make([]bool, g.Order())
+			}
+			c.vertexRemoved[i] = true
+			continue
+		}
+		c.vertexCount++
+		if data != nil && c.vertexData == nil {
+			c.vertexData = make([]interface{}, g.Order())
+		}
+		if data != nil {
+			c.vertexData[i] = data
+		}
+	}
+	for i := 0; i < g.Size(); i++ {
+		e, err := getEdge(g, i)
+		if err != nil {
+			if c.edgeRemoved == nil {
+				c.edgeRemoved = make([]bool, g.Size())
+			}
+			c.edgeRemoved[i] = true
+			c.endpoints[i] = [2]int{-1, -1}
+			continue
+		}
+		c.edgeCount++
+		vi, vj := e.Endpoints()
+		c.endpoints[i] = [2]int{vi, vj}
+		c.weights[i] = e.weight
+		if e.Data != nil && c.edgeData == nil {
+			c.edgeData = make([]interface{}, g.Size())
+		}
+		if e.Data != nil {
+			c.edgeData[i] = e.Data
+		}
+	}
+	return c
+}
+
+// returns an immutable compressed sparse row copy of this graph
+// the copy answers every query like this graph, with the same ids and adjacency order,
+// but keeps all the adjacency lists in one array instead of one array per vertex
+// and takes about half the memory. Adjacencies are faster to walk, while
+// GetVertex and GetEdge allocate a view on every call
+// later changes to this graph are not seen by the copy, see CSRGraph.Thaw
+func (g Graph) Freeze() CSRGraph {
+	return NewCSRGraph(g)
+}
+
+// appends a vertex without edges holding data
+// the arrays of c are appended to in place, copies of c made before keep their length
+// and never see the new vertex
+func (c *csr) appendVertex(data interface{}) {
+	c.offsets = append(c.offsets, c.offsets[len(c.offsets)-1])
+	if c.directed {
+		c.inOffsets = append(c.inOffsets, c.inOffsets[len(c.inOffsets)-1])
+	}
+	if c.vertexData != nil {
+		c.vertexData = append(c.vertexData, data)
+	} else if data != nil {
+		c.vertexData = make([]interface{}, len(c.offsets)-1)
+		c.vertexData[len(c.vertexData)-1] = data
+	}
+	if c.vertexRemoved != nil {
+		c.vertexRemoved = append(c.vertexRemoved, false)
+	}
+	c.vertexCount++
+}
+
+// packs the lists returned by list for every vertex of g into a single array
+// returns the offset of every list followed by the length of the array
+func packAdjacencies(g Interface, list func(Interface, int) []Adjacency) ([]int, []Adjacency) {
+	offsets := make([]int, g.Order()+1)
+	for v := 0; v < g.Order(); v++ {
+		offsets[v+1] = offsets[v]
+		if vertexExists(g, v) {
+			offsets[v+1] += len(list(g, v))
+		}
+	}
+	packed := make([]Adjacency, 0, offsets[g.Order()])
+	for v := 0; v < g.Order(); v++ {
+		if vertexExists(g, v) {
+			packed = append(packed, list(g, v)...)
+		}
+	}
+	return offsets, packed
+}
+
+// returns true if the edges of this graph are directed
+func (c CSRGraph) IsDirected() bool {
+	return c.directed
+}
+
+// returns the number of vertex ids of this graph
+func (c CSRGraph) Order() int {
+	return len(c.offsets) - 1
+}
+
+// returns the number of edge ids of this graph
+func (c CSRGraph) Size() int {
+	return len(c.endpoints)
+}
+
+// returns the number of vertexes of this graph, excluding removed ones
+func (c CSRGraph) VertexCount() int {
+	return c.vertexCount
+}
+
+// returns the number of edges of this graph, excluding removed ones
+func (c CSRGraph) EdgeCount() int {
+	return c.edgeCount
+}
+
+// returns true if 0 >= index < Order() and the vertex was not removed
+func (c CSRGraph) VertexExists(index int) bool {
+	if index < 0 || index >= c.Order() {
+		return false
+	}
+	return c.vertexRemoved == nil || !c.vertexRemoved[index]
+}
+
+// returns true if 0 >= index < Size() and the edge was not removed
+func (c CSRGraph) EdgeExists(index int) bool {
+	if index < 0 || index >= c.Size() {
+		return false
+	}
+	return c.edgeRemoved == nil || !c.edgeRemoved[index]
+}
+
+// returns an error if the vertex-index is out of bounds or was removed
+func (c CSRGraph) testVertex(index int) error {
+	if c.VertexExists(index) {
+		return nil
+	}
+	if index < 0 || index >= c.Order() {
+		return ErrOutOfBounds{"vertex", index}
+	}
+	return ErrRemoved{"vertex", index}
+}
+
+// returns an error if the edge-index is out of bounds or was removed
+func (c CSRGraph) testEdge(index int) error {
+	if c.EdgeExists(index) {
+		return nil
+	}
+	if index < 0 || index >= c.Size() {
+		return ErrOutOfBounds{"edge", index}
+	}
+	return ErrRemoved{"edge", index}
+}
+
+// returns the edges leaving vertexIndex and the vertexes they lead to
+// the returned array is shared with the graph and must not be modified
+func (c CSRGraph) Adjacencies(vertexIndex int) ([]Adjacency, error) {
+	if err := c.testVertex(vertexIndex); err != nil {
+		return nil, err
+	}
+	return c.out[c.offsets[vertexIndex]:c.offsets[vertexIndex+1]:c.offsets[vertexIndex+1]], nil
+}
+
+// returns the edges entering vertexIndex and the vertexes they come from
+// for undirected graphs this is the same as Adjacencies
+func (c CSRGraph) InAdjacencies(vertexIndex int) ([]Adjacency, error) {
+	if !c.directed {
+		return c.Adjacencies(vertexIndex)
+	}
+	if err := c.testVertex(vertexIndex); err != nil {
+		return nil, err
+	}
+	return c.in[c.inOffsets[vertexIndex]:c.inOffsets[vertexIndex+1]:c.inOffsets[vertexIndex+1]], nil
+}
+
+// returns the edges leaving vertexIndex, see Graph.GetAdjacencies
+func (c CSRGraph) GetAdjacencies(vertexIndex int) ([]Adjacency, error) {
+	return c.Adjacencies(vertexIndex)
+}
+
+// returns the edges entering vertexIndex, see Graph.GetInAdjacencies
+func (c CSRGraph) GetInAdjacencies(vertexIndex int) ([]Adjacency, error) {
+	return c.InAdjacencies(vertexIndex)
+}
+
+// returns the ids of the vertexes joined by the edge E[edgeIndex]
+func (c CSRGraph) Endpoints(edgeIndex int) (int, int, error) {
+	if err := c.testEdge(edgeIndex); err != nil {
+		return -1, -1, err
+	}
+	return c.endpoints[edgeIndex][0], c.endpoints[edgeIndex][1], nil
+}
+
+// returns the Data of the vertex V[vertexIndex]
+func (c CSRGraph) VertexData(vertexIndex int) (interface{}, error) {
+	if err := c.testVertex(vertexIndex); err != nil {
+		return nil, err
+	}
+	if c.vertexData == nil {
+		return nil, nil
+	}
+	return c.vertexData[vertexIndex], nil
+}
+
+// returns the Data of the edge E[edgeIndex]
+func (c CSRGraph) EdgeData(edgeIndex int) (interface{}, error) {
+	if err := c.testEdge(edgeIndex); err != nil {
+		return nil, err
+	}
+	if c.edgeData == nil {
+		return nil, nil
+	}
+	return c.edgeData[edgeIndex], nil
+}
+
+// returns the weight of the edge E[edgeIndex]
+func (c CSRGraph) Weight(edgeIndex int) (float64, error) {
+	if err := c.testEdge(edgeIndex); err != nil {
+		return 0, err
+	}
+	return c.weights[edgeIndex], nil
+}
+
+// returns a view of the vertex V[vertexIndex]
+// a new view is returned by every call, changes to it are not kept
+func (c CSRGraph) GetVertex(vertexIndex int) (*Vertex, error) {
+	out, err := c.Adjacencies(vertexIndex)
+	if err != nil {
+		return nil, err
+	}
+	v := &Vertex{id: vertexIndex, out: out, directed: c.directed}
+	if c.directed {
+		v.in, _ = c.InAdjacencies(vertexIndex)
+	}
+	if c.vertexData != nil {
+		v.Data = c.vertexData[vertexIndex]
+	}
+	return v, nil
+}
+
+// returns a view of the edge E[edgeIndex]
+// a new view is returned by every call, changes to it are not kept
+func (c CSRGraph) GetEdge(edgeIndex int) (*Edge, error) {
+	if err := c.testEdge(edgeIndex); err != nil {
+		return nil, err
+	}
+	e := &Edge{id: edgeIndex, endpoints: c.endpoints[edgeIndex], weight: c.weights[edgeIndex]}
+	if c.edgeData != nil {
+		e.Data = c.edgeData[edgeIndex]
+	}
+	return e, nil
+}
+
+// returns a path over this graph holding the single vertex start
+func (c CSRGraph) NewPath(start int) (Path, error) {
+	return NewPath(c, start)
+}
+
+// returns a modifiable adjacency list copy of this graph
+func (c CSRGraph) Thaw() Graph {
+	return ToGraph(c)
+}
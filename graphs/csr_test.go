@@ -0,0 +1,267 @@
+package graph
+
+import (
+	"math/rand"
+	"runtime"
+	"testing"
+)
+
+// TestFreeze:
+// Verify a frozen graph answers every query like the graph it was made from
+// and converts back to a graph that can grow
+func TestFreeze(t *testing.T) {
+	d := NewDigraph()
+	for i := 0; i < 6; i++ {
+		d.AddVertex(i)
+	}
+	d.AddWeightedEdge(0, 1, 4, nil)
+	d.AddWeightedEdge(0, 2, 1, nil)
+	d.AddWeightedEdge(2, 1, 2, nil)
+	d.AddWeightedEdge(1, 3, 5, nil)
+	d.AddWeightedEdge(2, 3, 8, nil)
+	d.AddWeightedEdge(3, 4, 3, nil)
+	d.AddWeightedEdge(2, 4, 1, nil)
+	d.AddWeightedEdge(4, 5, 1, nil)
+	d.RemoveEdge(6)
+	d.RemoveVertex(5)
+	random, err := ErdosRenyi(50, 0.15, 1)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	// random weights, self-loops, parallel edges and removals on top of the generated edges
+	rng := rand.New(rand.NewSource(1))
+	for e := 0; e < random.Size(); e++ {
+		random.SetWeight(e, float64(1+rng.Intn(10)))
+	}
+	for i := 0; i < 10; i++ {
+		v := rng.Intn(random.Order())
+		random.AddWeightedEdge(v, v, float64(rng.Intn(10)), v)
+		if vi, vj, err := random.Endpoints(rng.Intn(random.Size())); err == nil {
+			random.AddWeightedEdge(vi, vj, float64(rng.Intn(10)), nil)
+		}
+	}
+	for i := 0; i < 5; i++ {
+		random.RemoveEdge(rng.Intn(random.Size()))
+		random.RemoveVertex(rng.Intn(random.Order()))
+	}
+	if random.VertexCount() == random.Order() || random.EdgeCount() == random.Size() {
+		t.Fatalf("expected removed vertexes and edges, got %d of %d and %d of %d.", random.VertexCount(), random.Order(), random.EdgeCount(), random.Size())
+	}
+	for _, g := range []Graph{d, random} {
+		c := g.Freeze()
+		if c.Order() != g.Order() || c.Size() != g.Size() || c.VertexCount() != g.VertexCount() || c.EdgeCount() != g.EdgeCount() || c.IsDirected() != g.IsDirected() {
+			t.Fatalf("Freeze(): expected %d %d vertexes and %d %d edges, got %d %d and %d %d.",
+				g.Order(), g.VertexCount(), g.Size(), g.EdgeCount(), c.Order(), c.VertexCount(), c.Size(), c.EdgeCount())
+		}
+		for v := -1; v <= g.Order(); v++ {
+			out, err := g.GetAdjacencies(v)
+			cout, cerr := c.GetAdjacencies(v)
+			in, _ := g.GetInAdjacencies(v)
+			cin, _ := c.GetInAdjacencies(v)
+			if err != cerr || !sameAdjacencies(out, cout) || !sameAdjacencies(in, cin) || g.VertexExists(v) != c.VertexExists(v) {
+				t.Fatalf("Freeze(): vertex %d differs: %v %v %v, %v %v %v.", v, out, in, err, cout, cin, cerr)
+			}
+			if gv, err := g.GetVertex(v); err == nil {
+				cv, _ := c.GetVertex(v)
+				if gv.Data != cv.Data || gv.Degree() != cv.Degree() || gv.ID() != cv.ID() {
+					t.Fatalf("Freeze(): vertex %d differs: %v, %v.", v, gv, cv)
+				}
+			}
+		}
+		for e := -1; e <= g.Size(); e++ {
+			ge, err := g.GetEdge(e)
+			ce, cerr := c.GetEdge(e)
+			if err != cerr || g.EdgeExists(e) != c.EdgeExists(e) {
+				t.Fatalf("Freeze(): edge %d differs: %v, %v.", e, err, cerr)
+			}
+			if err == nil && (ge.endpoints != ce.endpoints || ge.weight != ce.weight || ge.Data != ce.Data) {
+				t.Fatalf("Freeze(): edge %d differs: %v, %v.", e, ge, ce)
+			}
+		}
+	}
+	c := d.Freeze()
+	g := c.Thaw()
+	if g.VertexCount() != 5 || g.EdgeCount() != 6 {
+		t.Fatalf("Thaw(): expected 5 vertexes and 6 edges, got %d and %d.", g.VertexCount(), g.EdgeCount())
+	}
+	g.AddEdge(4, 0, nil)
+	if out, _ := c.Adjacencies(4); len(out) != 0 {
+		t.Fatalf("Thaw(): the CSR graph changed: %v.", out)
+	}
+	if _, err := TopologicalSort(g); err == nil {
+		t.Fatalf("Thaw(): expected a cycle after adding 4->0.")
+	}
+}
+
+// TestFreezeAlgorithms:
+// Verify algorithms follow the same adjacency order on a frozen graph
+func TestFreezeAlgorithms(t *testing.T) {
+	g, err := ErdosRenyi(40, 0.15, 2)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	c := g.Freeze()
+	p, err := Trace(g, 0)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	q, err := Trace(c, 0)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if !sameAdjacencies(p.out, q.out) {
+		t.Fatalf("Trace(): expected %v, got %v.", p.out, q.out)
+	}
+	path, err := c.NewPath(0)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if path, err = path.Grow(c.out[0].Endpoint, c.out[0].Edge); err != nil || path.GetLastVertex() != c.out[0].Endpoint {
+		t.Fatalf("Grow(): unexpected path %v %v.", path.out, err)
+	}
+}
+
+// returns true if both adjacency lists hold the same entries in the same order
+func sameAdjacencies(a, b []Adjacency) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// returns the heap bytes still in use by the graph returned by build
+func liveBytes(build func() Interface) uint64 {
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	g := build()
+	runtime.GC()
+	runtime.ReadMemStats(&after)
+	runtime.KeepAlive(g)
+	return after.HeapAlloc - before.HeapAlloc
+}
+
+// the benchmarks run on a random 20-regular graph
+const benchmarkOrder, benchmarkSize = 100000, 1000000
+
+// returns the random graph used by the benchmarks
+func benchmarkSource(b *testing.B) Graph {
+	g, err := RandomRegular(benchmarkOrder, 2*benchmarkSize/benchmarkOrder, 3)
+	if err != nil {
+		b.Fatal(err)
+	}
+	return g
+}
+
+var benchmarkGraph *Graph
+
+var benchmarkCSR *CSRGraph
+
+// returns the graph shared by the benchmarks and its frozen copy,
+// built on first use so that tests do not pay for them
+func benchmarkGraphs(b *testing.B) (Graph, CSRGraph) {
+	if benchmarkGraph == nil {
+		g := benchmarkSource(b)
+		c := g.Freeze()
+		benchmarkGraph, benchmarkCSR = &g, &c
+	}
+	return *benchmarkGraph, *benchmarkCSR
+}
+
+// reports the heap bytes per edge taken by a graph built by build
+func benchmarkMemory(b *testing.B, build func() Interface) {
+	var bytes uint64
+	for i := 0; i < b.N; i++ {
+		bytes = liveBytes(build)
+	}
+	b.ReportMetric(float64(bytes)/benchmarkSize, "B/edge")
+}
+
+func BenchmarkMemoryGraph(b *testing.B) {
+	benchmarkMemory(b, func() Interface { return benchmarkSource(b) })
+}
+
+func BenchmarkMemoryCSR(b *testing.B) {
+	// the source graph is built outside of the measure
+	g, _ := benchmarkGraphs(b)
+	benchmarkMemory(b, func() Interface { return g.Freeze() })
+}
+
+func BenchmarkFreeze(b *testing.B) {
+	g, _ := benchmarkGraphs(b)
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		g.Freeze()
+	}
+}
+
+// visits every vertex reachable from vertex 0
+func benchmarkBFS(b *testing.B, g Interface) {
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := BFS(g, 0, -1, Visitor{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkBFSGraph(b *testing.B) {
+	g, _ := benchmarkGraphs(b)
+	benchmarkBFS(b, g)
+}
+
+func BenchmarkBFSCSR(b *testing.B) {
+	_, c := benchmarkGraphs(b)
+	benchmarkBFS(b, c)
+}
+
+// computes the shortest paths from vertex 0
+func benchmarkDijkstra(b *testing.B, g Interface) {
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := Dijkstra(g, 0, nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDijkstraGraph(b *testing.B) {
+	g, _ := benchmarkGraphs(b)
+	benchmarkDijkstra(b, g)
+}
+
+func BenchmarkDijkstraCSR(b *testing.B) {
+	_, c := benchmarkGraphs(b)
+	benchmarkDijkstra(b, c)
+}
+
+// TestFrozenCosts:
+// Verify weighted algorithms do not build a view of an edge of a frozen graph per relaxation
+func TestFrozenCosts(t *testing.T) {
+	g, err := ErdosRenyi(200, 0.05, 4)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	c := g.Freeze()
+	double := func(e *Edge) float64 { return 2 * e.Weight() }
+	for _, w := range []WeightFunc{nil, double} {
+		listAllocs := testing.AllocsPerRun(5, func() { BellmanFord(g, 0, w) })
+		frozenAllocs := testing.AllocsPerRun(5, func() { BellmanFord(c, 0, w) })
+		// a custom weight function gets one view per edge and its costs are kept in two arrays
+		limit := listAllocs
+		if w != nil {
+			limit += float64(c.Size() + 2)
+		}
+		if frozenAllocs > limit {
+			t.Fatalf("BellmanFord(): expected at most %v allocations on the frozen graph, got %v.", limit, frozenAllocs)
+		}
+	}
+}
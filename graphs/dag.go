@@ -0,0 +1,220 @@
+package graph
+
+import (
+	"fmt"
+	"sort"
+)
+
+var ErrUndirected = fmt.Errorf("operation requires a directed graph")
+
+var ErrDirected = fmt.Errorf("operation requires an undirected graph")
+
+// returned when a directed graph is expected to be acyclic but it is not
+// Cycle is a closed path through the offending cycle
+type ErrCycle struct {
+	Cycle Path
+}
+
+func (err ErrCycle) Error() string {
+	return fmt.Sprintf("graph has a cycle through vertex: %d", err.Cycle.start)
+}
+
+// returns the vertexes of a directed acyclic graph in topological order,
+// so that every edge goes from a vertex to a later one, using Kahn's algorithm
+// an ErrCycle is returned if the graph has a cycle
+func TopologicalSort(g Interface) ([]int, error) {
+	if !g.IsDirected() {
+		return nil, ErrUndirected
+	}
+	inDegree := make([]int, g.Order())
+	ready := make([]int, 0)
+	for v := 0; v < g.Order(); v++ {
+		inDegree[v] = len(inAdjacencies(g, v))
+		if inDegree[v] == 0 && vertexExists(g, v) {
+			ready = append(ready, v)
+		}
+	}
+	order := make([]int, 0, vertexCount(g))
+	for len(ready) > 0 {
+		u := ready[0]
+		ready = ready[1:]
+		order = append(order, u)
+		for _, o := range adjacencies(g, u) {
+			inDegree[o.Endpoint]--
+			if inDegree[o.Endpoint] == 0 {
+				ready = append(ready, o.Endpoint)
+			}
+		}
+	}
+	if len(order) < vertexCount(g) {
+		return nil, findCycle(g)
+	}
+	return order, nil
+}
+
+// returns the vertexes of a directed acyclic graph in topological order
+// computed as the reverse of the order in which a depth first search finishes them
+// an ErrCycle is returned if the graph has a cycle
+func TopologicalSortDFS(g Interface) ([]int, error) {
+	if !g.IsDirected() {
+		return nil, ErrUndirected
+	}
+	order := make([]int, vertexCount(g))
+	next := vertexCount(g) - 1
+	cyclic := false
+	err := DFSAll(g, Visitor{
+		FinishVertex: func(v int) error {
+			order[next] = v
+			next--
+			return nil
+		},
+		BackEdge: func(from, to, edge int) error {
+			cyclic = true
+			return ErrStop
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if cyclic {
+		return nil, findCycle(g)
+	}
+	return order, nil
+}
+
+// searches a cycle of g through depth first search
+// returns an ErrCycle holding the cycle found, or nil if g is acyclic
+func findCycle(g Interface) error {
+	parent := make([]Adjacency, g.Order())
+	var cycle error
+	DFSAll(g, Visitor{
+		TreeEdge: func(from, to, edge int) error {
+			parent[to] = Adjacency{from, edge}
+			return nil
+		},
+		BackEdge: func(from, to, edge int) error {
+			// the tree path from to down to from, closed by the back edge
+			steps := []Adjacency{{to, edge}}
+			for v := from; v != to; v = parent[v].Endpoint {
+				steps = append(steps, Adjacency{v, parent[v].Edge})
+			}
+			path, err := NewPath(g, to)
+			if err == nil {
+				path, err = path.growSteps(reverseSteps(steps))
+			}
+			if err != nil {
+				cycle = err
+			} else {
+				cycle = ErrCycle{path}
+			}
+			return ErrStop
+		},
+	})
+	return cycle
+}
+
+// returns a path of maximum cost in a directed acyclic graph and its cost
+// edge costs are given by w, or by the edge weights if w is nil
+// an ErrCycle is returned if the graph has a cycle
+func LongestPath(g Interface, w WeightFunc) (Path, float64, error) {
+	order, err := TopologicalSort(g)
+	if err != nil {
+		return Path{}, 0, err
+	}
+	if len(order) == 0 {
+		return Path{}, 0, ErrEmptyGraph
+	}
+	costOf := edgeCosts(g, w)
+	// every vertex can start a path, so the longest path to it costs at least 0
+	dist := make([]float64, g.Order())
+	prev := make([]Adjacency, g.Order())
+	for v := range prev {
+		prev[v] = Adjacency{-1, -1}
+	}
+	for _, u := range order {
+		for _, o := range adjacencies(g, u) {
+			if d := dist[u] + costOf(o.Edge); d > dist[o.Endpoint] {
+				dist[o.Endpoint] = d
+				prev[o.Endpoint] = Adjacency{u, o.Edge}
+			}
+		}
+	}
+	end := order[0]
+	for _, v := range order {
+		if dist[v] > dist[end] {
+			end = v
+		}
+	}
+	start := end
+	for prev[start].Edge != -1 {
+		start = prev[start].Endpoint
+	}
+	path, err := buildPath(g, start, end, prev)
+	return path, dist[end], err
+}
+
+// returns the transitive reduction of a directed acyclic graph: the graph with the same
+// vertexes and the fewest edges of g that keep the same reachability between vertexes
+// vertexes keep their ids and Data, removed vertexes included, kept edges keep their weight and Data
+// returns the reduced graph and, for each of its edges, the id of the edge of g it comes from
+// an ErrCycle is returned if the graph has a cycle
+func TransitiveReduction(g Interface) (Graph, []int, error) {
+	reduced := NewDigraph()
+	order, err := TopologicalSort(g)
+	if err != nil {
+		return reduced, nil, err
+	}
+	position := make([]int, g.Order())
+	for i, v := range order {
+		position[v] = i
+	}
+	words := (g.Order() + 63) / 64
+	reach := make([][]uint64, g.Order())
+	for i := len(order) - 1; i >= 0; i-- {
+		u := order[i]
+		reach[u] = make([]uint64, words)
+		for _, o := range adjacencies(g, u) {
+			v := o.Endpoint
+			reach[u][v/64] |= 1 << (v % 64)
+			for k := range reach[u] {
+				reach[u][k] |= reach[v][k]
+			}
+		}
+	}
+
+	for v := 0; v < g.Order(); v++ {
+		data, err := g.VertexData(v)
+		if err != nil {
+			reduced.AddVertex(nil)
+			reduced.RemoveVertex(v)
+			continue
+		}
+		reduced.AddVertex(data)
+	}
+	kept := make([]int, 0)
+	covered := make([]uint64, words)
+	for u := 0; u < g.Order(); u++ {
+		successors := append([]Adjacency{}, adjacencies(g, u)...)
+		sort.SliceStable(successors, func(i, j int) bool {
+			return position[successors[i].Endpoint] < position[successors[j].Endpoint]
+		})
+		for k := range covered {
+			covered[k] = 0
+		}
+		// a successor reached through an earlier successor does not need a direct edge
+		for _, o := range successors {
+			v := o.Endpoint
+			if covered[v/64]&(1<<(v%64)) != 0 {
+				continue
+			}
+			covered[v/64] |= 1 << (v % 64)
+			for k := range covered {
+				covered[k] |= reach[v][k]
+			}
+			e := edgeAt(g, o.Edge)
+			reduced.AddWeightedEdge(u, v, e.weight, e.Data)
+			kept = append(kept, o.Edge)
+		}
+	}
+	return reduced, kept, nil
+}
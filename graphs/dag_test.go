@@ -0,0 +1,104 @@
+package graph
+
+import "testing"
+
+// verifies every edge of g goes forward in order
+func checkTopological(t *testing.T, g Graph, order []int) {
+	position := make([]int, g.Order())
+	for i, v := range order {
+		position[v] = i
+	}
+	for _, e := range g.edges {
+		if position[e.endpoints[0]] >= position[e.endpoints[1]] {
+			t.Fatalf("order %v: edge %v goes backwards.", order, e.endpoints)
+		}
+	}
+}
+
+// TestTopologicalSort:
+// Verify both variants and the cycle reported
+func TestTopologicalSort(t *testing.T) {
+	g := NewDigraph()
+	for i := 0; i < 5; i++ {
+		g.AddVertex(i)
+	}
+	g.AddWeightedEdge(0, 1, 1, nil)
+	g.AddWeightedEdge(0, 2, 4, nil)
+	g.AddWeightedEdge(1, 3, 1, nil)
+	g.AddWeightedEdge(2, 3, 1, nil)
+	g.AddWeightedEdge(3, 4, 1, nil)
+	g.AddWeightedEdge(0, 3, 1, nil)
+	g.AddWeightedEdge(0, 4, 1, nil)
+	order, err := TopologicalSort(g)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	checkTopological(t, g, order)
+	order, err = TopologicalSortDFS(g)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	checkTopological(t, g, order)
+	g.AddEdge(4, 1, nil)
+	for _, sort := range []func(Interface) ([]int, error){TopologicalSort, TopologicalSortDFS} {
+		_, err = sort(g)
+		cycleErr, ok := err.(ErrCycle)
+		if !ok {
+			t.Fatalf("expected a cycle error, got '%v'.", err)
+		}
+		if len(cycleErr.Cycle.out) != 3 || cycleErr.Cycle.GetLastVertex() != cycleErr.Cycle.start {
+			t.Fatalf("expected the cycle 1 3 4 1, got %v.", cycleErr.Cycle.out)
+		}
+	}
+}
+
+// TestLongestPath:
+// Verify the critical path of a DAG
+func TestLongestPath(t *testing.T) {
+	g := NewDigraph()
+	for i := 0; i < 5; i++ {
+		g.AddVertex(i)
+	}
+	g.AddWeightedEdge(0, 1, 1, nil)
+	g.AddWeightedEdge(0, 2, 4, nil)
+	g.AddWeightedEdge(1, 3, 1, nil)
+	g.AddWeightedEdge(2, 3, 1, nil)
+	g.AddWeightedEdge(3, 4, 1, nil)
+	g.AddWeightedEdge(0, 3, 1, nil)
+	g.AddWeightedEdge(0, 4, 1, nil)
+	path, cost, err := LongestPath(g, nil)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if cost != 6 || path.start != 0 || len(path.out) != 3 || path.out[0].Endpoint != 2 {
+		t.Fatalf("LongestPath(): expected path 0 2 3 4 of cost 6, got %v of cost %v.", path.out, cost)
+	}
+}
+
+// TestTransitiveReduction:
+// Verify redundant edges are dropped
+func TestTransitiveReduction(t *testing.T) {
+	g := NewDigraph()
+	for i := 0; i < 5; i++ {
+		g.AddVertex(i)
+	}
+	g.AddWeightedEdge(0, 1, 1, nil)
+	g.AddWeightedEdge(0, 2, 4, nil)
+	g.AddWeightedEdge(1, 3, 1, nil)
+	g.AddWeightedEdge(2, 3, 1, nil)
+	g.AddWeightedEdge(3, 4, 1, nil)
+	g.AddWeightedEdge(0, 3, 1, nil)
+	g.AddWeightedEdge(0, 4, 1, nil)
+	reduced, kept, err := TransitiveReduction(g)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if reduced.Size() != 5 || len(kept) != 5 {
+		t.Fatalf("TransitiveReduction(): expected 5 edges, got %d: %v.", reduced.Size(), kept)
+	}
+	for _, e := range kept {
+		if e == 5 || e == 6 {
+			t.Fatalf("TransitiveReduction(): redundant edge %d was kept.", e)
+		}
+	}
+}
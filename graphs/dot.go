@@ -0,0 +1,492 @@
+package graph
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// options of WriteDOT
+// any field can be left empty
+type DOTOptions struct {
+	// name of the graph, "G" if empty
+	Name string
+	// returns the label of a vertex, fmt.Sprint of its Data if nil
+	VertexLabel func(v *Vertex) string
+	// returns the label of an edge, fmt.Sprint of its Data if nil
+	EdgeLabel func(e *Edge) string
+	// returns additional attributes of a vertex, such as "shape" or "color"
+	VertexAttributes func(v *Vertex) map[string]string
+	// returns additional attributes of an edge
+	EdgeAttributes func(e *Edge) map[string]string
+	// vertexes and edges of this path are drawn in red
+	Highlight *Path
+}
+
+// the DOT attribute holding the weight of an edge, written by WriteDOT and read by ReadDOT
+// the Graphviz "weight" attribute is not used: it is a layout hint restricted to non-negative integers
+const DOTWeightAttribute = "cost"
+
+// writes g to w in the Graphviz DOT language
+// every vertex is written as a node named after its id, every edge as an edge
+// between the nodes of its endpoints, both labelled with their Data.
+// nil Data is written without a label. weights other than 1 are written in the
+// DOTWeightAttribute of edges. removed vertexes and edges are skipped.
+// Data holding a DOTElement, as read by ReadDOT, is written back as its attributes
+// instead of a label, and a vertex holding one is written as the node of its Name,
+// so that writing a graph read by ReadDOT gives the same graph when read again.
+// a node whose name is already taken is named after its id prefixed with underscores
+func WriteDOT(w io.Writer, g Interface, options DOTOptions) error {
+	name := options.Name
+	if name == "" {
+		name = "G"
+	}
+	kind, connector := "graph", "--"
+	if g.IsDirected() {
+		kind, connector = "digraph", "->"
+	}
+	highlightedVertexes := make(map[int]bool)
+	highlightedEdges := make(map[int]bool)
+	if p := options.Highlight; p != nil {
+		highlightedVertexes[p.start] = true
+		for _, o := range p.out {
+			highlightedVertexes[o.Endpoint] = true
+			highlightedEdges[o.Edge] = true
+		}
+	}
+	nodes := dotNodeNames(g)
+	out := bufio.NewWriter(w)
+	fmt.Fprintf(out, "%s %s {\n", kind, quoteDOT(name))
+	for i := 0; i < g.Order(); i++ {
+		v, err := getVertex(g, i)
+		if err != nil {
+			// removed vertex
+			continue
+		}
+		element, isElement := v.Data.(DOTElement)
+		attributes := make(map[string]string)
+		for key, value := range element.Attributes {
+			attributes[key] = value
+		}
+		if options.VertexAttributes != nil {
+			for key, value := range options.VertexAttributes(v) {
+				attributes[key] = value
+			}
+		}
+		if options.VertexLabel != nil {
+			attributes["label"] = options.VertexLabel(v)
+		} else if v.Data != nil && !isElement {
+			attributes["label"] = fmt.Sprint(v.Data)
+		}
+		if highlightedVertexes[v.id] {
+			attributes["color"] = "red"
+		}
+		fmt.Fprintf(out, "\t%s%s;\n", quoteDOT(nodes[v.id]), formatDOTAttributes(attributes))
+	}
+	for i := 0; i < g.Size(); i++ {
+		e, err := getEdge(g, i)
+		if err != nil {
+			// removed edge
+			continue
+		}
+		element, isElement := e.Data.(DOTElement)
+		attributes := make(map[string]string)
+		for key, value := range element.Attributes {
+			attributes[key] = value
+		}
+		// the weight may have changed since the element was read
+		delete(attributes, DOTWeightAttribute)
+		if options.EdgeAttributes != nil {
+			for key, value := range options.EdgeAttributes(e) {
+				attributes[key] = value
+			}
+		}
+		if options.EdgeLabel != nil {
+			attributes["label"] = options.EdgeLabel(e)
+		} else if e.Data != nil && !isElement {
+			attributes["label"] = fmt.Sprint(e.Data)
+		}
+		if e.weight != 1 {
+			attributes[DOTWeightAttribute] = strconv.FormatFloat(e.weight, 'g', -1, 64)
+		}
+		if highlightedEdges[e.id] {
+			attributes["color"] = "red"
+			attributes["penwidth"] = "2"
+		}
+		fmt.Fprintf(out, "\t%s %s %s%s;\n", quoteDOT(nodes[e.endpoints[0]]), connector, quoteDOT(nodes[e.endpoints[1]]), formatDOTAttributes(attributes))
+	}
+	fmt.Fprintln(out, "}")
+	return out.Flush()
+}
+
+// returns the DOT node name of every vertex of g, see WriteDOT
+// names of DOTElements are given out first, in order of vertex id
+func dotNodeNames(g Interface) []string {
+	names := make([]string, g.Order())
+	taken := make(map[string]bool)
+	for i := range names {
+		data, _ := g.VertexData(i)
+		if element, ok := data.(DOTElement); ok && element.Name != "" && !taken[element.Name] {
+			names[i] = element.Name
+			taken[element.Name] = true
+		}
+	}
+	for i := range names {
+		if names[i] != "" || !vertexExists(g, i) {
+			continue
+		}
+		name := strconv.Itoa(i)
+		for taken[name] {
+			name = "_" + name
+		}
+		names[i] = name
+		taken[name] = true
+	}
+	return names
+}
+
+// formats attributes as a DOT attribute list sorted by key
+func formatDOTAttributes(attributes map[string]string) string {
+	if len(attributes) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		pairs[i] = fmt.Sprintf("%s=%s", quoteDOT(key), quoteDOT(attributes[key]))
+	}
+	return " [" + strings.Join(pairs, ", ") + "]"
+}
+
+// returns id as is if it is a valid DOT identifier or number, or quoted otherwise
+func quoteDOT(id string) string {
+	if isDOTIdentifier(id) {
+		return id
+	}
+	if _, err := strconv.ParseFloat(id, 64); err == nil && !strings.ContainsAny(id, "eEinfINFxX+") {
+		return id
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(id) + `"`
+}
+
+func isDOTIdentifier(id string) bool {
+	if id == "" || unicode.IsDigit(rune(id[0])) {
+		return false
+	}
+	for _, r := range id {
+		if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	switch strings.ToLower(id) {
+	case "graph", "digraph", "node", "edge", "subgraph", "strict":
+		return false
+	}
+	return true
+}
+
+// a node or edge read by ReadDOT
+// Attributes holds every attribute given to it in the DOT source
+type DOTElement struct {
+	// name of the node in the DOT source. empty for edges
+	Name       string
+	Attributes map[string]string
+}
+
+// reads a graph written in the Graphviz DOT language
+// vertexes are created in order of first appearance of their node, and edges in order of appearance.
+// the Data of every vertex and edge is a DOTElement holding its attributes,
+// and the DOTWeightAttribute of edges, if given, sets their weight.
+// only a subset of the language is supported: a single graph or digraph with
+// node statements, edge statements and chains (a -- b -- c), attribute lists and
+// graph/node/edge default attribute statements. subgraphs and ports are not supported
+func ReadDOT(r io.Reader) (Graph, error) {
+	source, err := io.ReadAll(r)
+	if err != nil {
+		return Graph{}, err
+	}
+	p := &dotParser{tokens: tokenizeDOT(string(source))}
+	return p.parse()
+}
+
+// a token of DOT source
+// quoted strings are never taken for keywords or punctuation
+type dotToken struct {
+	text   string
+	quoted bool
+}
+
+type dotParser struct {
+	tokens []dotToken
+	pos    int
+	g      Graph
+	nodes  map[string]int
+	// default attributes given by "node [...]" and "edge [...]"
+	nodeDefaults map[string]string
+	edgeDefaults map[string]string
+}
+
+// returns the text of the next token, or "" at the end of the input
+func (p *dotParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos].text
+	}
+	return ""
+}
+
+// returns true if the next token is the unquoted keyword or punctuation text
+func (p *dotParser) at(text string) bool {
+	return p.pos < len(p.tokens) && !p.tokens[p.pos].quoted && strings.EqualFold(p.tokens[p.pos].text, text)
+}
+
+func (p *dotParser) next() string {
+	token := p.peek()
+	p.pos++
+	return token
+}
+
+// returns the next token if it is an identifier, that is anything but punctuation
+// returns an error for punctuation and at the end of the input
+func (p *dotParser) id() (string, error) {
+	if p.pos >= len(p.tokens) {
+		return "", fmt.Errorf("dot: unexpected end of input")
+	}
+	if token := p.tokens[p.pos]; !token.quoted && isDOTPunctuation(token.text) {
+		return "", fmt.Errorf("dot: expected an identifier, got %q", token.text)
+	}
+	return p.next(), nil
+}
+
+// returns true for the punctuation tokens of DOT
+func isDOTPunctuation(text string) bool {
+	return text == "--" || text == "->" || len(text) == 1 && strings.Contains("{}[]=;,", text)
+}
+
+func (p *dotParser) expect(text string) error {
+	if !p.at(text) {
+		return fmt.Errorf("dot: expected %q, got %q", text, p.peek())
+	}
+	p.pos++
+	return nil
+}
+
+func (p *dotParser) parse() (Graph, error) {
+	if p.at("strict") {
+		p.next()
+	}
+	switch {
+	case p.at("graph"):
+		p.g = NewGraph()
+	case p.at("digraph"):
+		p.g = NewDigraph()
+	default:
+		return Graph{}, fmt.Errorf("dot: expected graph or digraph, got %q", p.peek())
+	}
+	p.next()
+	if !p.at("{") {
+		p.next()
+	}
+	if err := p.expect("{"); err != nil {
+		return Graph{}, err
+	}
+	p.nodes = make(map[string]int)
+	p.nodeDefaults = make(map[string]string)
+	p.edgeDefaults = make(map[string]string)
+	for !p.at("}") {
+		if p.pos >= len(p.tokens) {
+			return Graph{}, fmt.Errorf("dot: unexpected end of input")
+		}
+		if err := p.statement(); err != nil {
+			return Graph{}, err
+		}
+		if p.at(";") {
+			p.next()
+		}
+	}
+	return p.g, nil
+}
+
+func (p *dotParser) statement() error {
+	if p.at("subgraph") || p.at("{") {
+		return fmt.Errorf("dot: subgraphs are not supported")
+	}
+	for keyword, defaults := range map[string]map[string]string{"graph": nil, "node": p.nodeDefaults, "edge": p.edgeDefaults} {
+		if !p.at(keyword) {
+			continue
+		}
+		p.next()
+		attributes, err := p.attributes()
+		if err != nil {
+			return err
+		}
+		for key, value := range attributes {
+			if defaults != nil {
+				defaults[key] = value
+			}
+		}
+		return nil
+	}
+	first, err := p.id()
+	if err != nil {
+		return err
+	}
+	if p.at("=") {
+		// graph attribute: id = id
+		p.next()
+		_, err := p.id()
+		return err
+	}
+	chain := []string{first}
+	for p.at("--") || p.at("->") {
+		if p.at("->") != p.g.directed {
+			return fmt.Errorf("dot: edge operator %q does not match the graph type", p.peek())
+		}
+		p.next()
+		id, err := p.id()
+		if err != nil {
+			return err
+		}
+		chain = append(chain, id)
+	}
+	attributes := make(map[string]string)
+	if p.at("[") {
+		var err error
+		if attributes, err = p.attributes(); err != nil {
+			return err
+		}
+	}
+	if len(chain) == 1 {
+		p.node(chain[0], attributes)
+		return nil
+	}
+	for i := 1; i < len(chain); i++ {
+		vi, vj := p.node(chain[i-1], nil), p.node(chain[i], nil)
+		element := DOTElement{Attributes: make(map[string]string)}
+		for key, value := range p.edgeDefaults {
+			element.Attributes[key] = value
+		}
+		for key, value := range attributes {
+			element.Attributes[key] = value
+		}
+		weight := 1.0
+		if value, ok := element.Attributes[DOTWeightAttribute]; ok {
+			var err error
+			if weight, err = strconv.ParseFloat(value, 64); err != nil {
+				return fmt.Errorf("dot: invalid weight %q", value)
+			}
+		}
+		if _, err := p.g.AddWeightedEdge(vi, vj, weight, element); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// returns the vertex of the node called name, creating it if needed,
+// and merges attributes into its Data
+func (p *dotParser) node(name string, attributes map[string]string) int {
+	id, ok := p.nodes[name]
+	if !ok {
+		element := DOTElement{Name: name, Attributes: make(map[string]string)}
+		for key, value := range p.nodeDefaults {
+			element.Attributes[key] = value
+		}
+		id = p.g.AddVertex(element)
+		p.nodes[name] = id
+	}
+	for key, value := range attributes {
+		p.g.vertexes[id].Data.(DOTElement).Attributes[key] = value
+	}
+	return id
+}
+
+// parses one or more attribute lists: [a=b, c=d][e=f]
+func (p *dotParser) attributes() (map[string]string, error) {
+	attributes := make(map[string]string)
+	for p.at("[") {
+		p.next()
+		for !p.at("]") {
+			if p.pos >= len(p.tokens) {
+				return nil, fmt.Errorf("dot: unexpected end of input")
+			}
+			key, err := p.id()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect("="); err != nil {
+				return nil, err
+			}
+			if attributes[key], err = p.id(); err != nil {
+				return nil, err
+			}
+			if p.at(",") || p.at(";") {
+				p.next()
+			}
+		}
+		p.next()
+	}
+	return attributes, nil
+}
+
+// splits DOT source into tokens, unquoting quoted strings and dropping comments
+func tokenizeDOT(source string) []dotToken {
+	tokens := make([]dotToken, 0)
+	runes := []rune(source)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '#' || r == '/' && i+1 < len(runes) && runes[i+1] == '/':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			i += 2
+			for i+1 < len(runes) && !(runes[i] == '*' && runes[i+1] == '/') {
+				i++
+			}
+			i += 2
+		case r == '"':
+			var b strings.Builder
+			for i++; i < len(runes) && runes[i] != '"'; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+					switch runes[i] {
+					case 'n':
+						b.WriteRune('\n')
+						continue
+					case '"', '\\':
+					default:
+						b.WriteRune('\\')
+					}
+				}
+				b.WriteRune(runes[i])
+			}
+			i++
+			tokens = append(tokens, dotToken{b.String(), true})
+		case r == '-' && i+1 < len(runes) && (runes[i+1] == '-' || runes[i+1] == '>'):
+			tokens = append(tokens, dotToken{string(runes[i : i+2]), false})
+			i += 2
+		case strings.ContainsRune("{}[]=;,", r):
+			tokens = append(tokens, dotToken{string(r), false})
+			i++
+		default:
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && !strings.ContainsRune("{}[]=;,\"", runes[i]) &&
+				!(runes[i] == '-' && i+1 < len(runes) && (runes[i+1] == '-' || runes[i+1] == '>') && i > start) {
+				i++
+			}
+			tokens = append(tokens, dotToken{string(runes[start:i]), false})
+		}
+	}
+	return tokens
+}
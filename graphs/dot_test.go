@@ -0,0 +1,155 @@
+package graph
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestWriteDOT:
+// Verify vertexes, edges, labels and highlighted paths are written
+func TestWriteDOT(t *testing.T) {
+	g := NewDigraph()
+	g.AddVertex("start")
+	g.AddVertex("a \"quoted\" label")
+	g.AddVertex(nil)
+	g.AddEdge(0, 1, "E1")
+	g.AddWeightedEdge(1, 2, 2.5, nil)
+	path, _ := g.NewPath(0)
+	path, _ = path.Grow(1, 0)
+	var b bytes.Buffer
+	err := WriteDOT(&b, g, DOTOptions{
+		Highlight:        &path,
+		VertexAttributes: func(v *Vertex) map[string]string { return map[string]string{"shape": "box"} },
+	})
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	expected := `digraph G {
+	0 [color=red, label=start, shape=box];
+	1 [color=red, label="a \"quoted\" label", shape=box];
+	2 [shape=box];
+	0 -> 1 [color=red, label=E1, penwidth=2];
+	1 -> 2 [cost=2.5];
+}
+`
+	if b.String() != expected {
+		t.Fatalf("WriteDOT(): expected\n%s\ngot\n%s", expected, b.String())
+	}
+
+	// a node named after the id of another vertex moves that vertex aside
+	h := NewGraph()
+	h.AddVertex(DOTElement{Name: "1", Attributes: map[string]string{"color": "blue"}})
+	h.AddVertex(nil)
+	h.AddEdge(0, 1, DOTElement{Attributes: map[string]string{DOTWeightAttribute: "3", "style": "dashed"}})
+	b.Reset()
+	WriteDOT(&b, h, DOTOptions{})
+	expected = `graph G {
+	1 [color=blue];
+	_1;
+	1 -- _1 [style=dashed];
+}
+`
+	if b.String() != expected {
+		t.Fatalf("WriteDOT(): expected\n%s\ngot\n%s", expected, b.String())
+	}
+}
+
+// TestReadDOT:
+// Verify a graph written by WriteDOT and hand written DOT can be read back
+func TestReadDOT(t *testing.T) {
+	g := Graph{}
+	for i := 0; i < 3; i++ {
+		g.AddVertex(i)
+	}
+	g.AddEdge(0, 1, "E1")
+	g.AddWeightedEdge(1, 2, 4, "E2")
+	var b bytes.Buffer
+	WriteDOT(&b, g, DOTOptions{})
+	read, err := ReadDOT(&b)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if read.IsDirected() || read.Order() != 3 || read.Size() != 2 {
+		t.Fatalf("ReadDOT(): expected an undirected graph with 3 vertexes and 2 edges, got %d and %d.", read.Order(), read.Size())
+	}
+	e, _ := read.GetEdge(1)
+	if e.Weight() != 4 || e.Data.(DOTElement).Attributes["label"] != "E2" {
+		t.Fatalf("ReadDOT(): expected edge E2 of weight 4, got %v of weight %v.", e.Data, e.Weight())
+	}
+
+	source := `/* pipeline */
+	digraph "build" {
+		rankdir=LR;
+		node [shape=box]
+		fetch -> compile -> "link step" [label="next"]; // chain
+		test [color=blue]
+		compile -> test
+	}`
+	read, err = ReadDOT(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if !read.IsDirected() || read.Order() != 4 || read.Size() != 3 {
+		t.Fatalf("ReadDOT(): expected a digraph with 4 vertexes and 3 edges, got %d and %d.", read.Order(), read.Size())
+	}
+	v, _ := read.GetVertex(2)
+	if element := v.Data.(DOTElement); element.Name != "link step" || element.Attributes["shape"] != "box" {
+		t.Fatalf("ReadDOT(): unexpected vertex 2: %v.", element)
+	}
+	v, _ = read.GetVertex(3)
+	if element := v.Data.(DOTElement); element.Name != "test" || element.Attributes["color"] != "blue" {
+		t.Fatalf("ReadDOT(): unexpected vertex 3: %v.", element)
+	}
+	// writing what was read gives back the same graph and the same source
+	b.Reset()
+	if err := WriteDOT(&b, read, DOTOptions{}); err != nil {
+		t.Fatalf(err.Error())
+	}
+	written := b.String()
+	again, err := ReadDOT(&b)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if !reflect.DeepEqual(again.vertexes, read.vertexes) || !reflect.DeepEqual(again.edges, read.edges) {
+		t.Fatalf("ReadDOT(WriteDOT()): expected the graph read, got\n%s", written)
+	}
+	b.Reset()
+	WriteDOT(&b, again, DOTOptions{})
+	if b.String() != written || !strings.Contains(written, `compile -> "link step" [label=next]`) {
+		t.Fatalf("WriteDOT(): expected a stable source, got\n%s\nthen\n%s", written, b.String())
+	}
+	if _, err := ReadDOT(strings.NewReader("graph { a -> b }")); err == nil {
+		t.Fatalf("ReadDOT(): expected an error for a directed edge in an undirected graph")
+	}
+	for _, source := range []string{"graph { a; ; b }", "graph { a -- ; }", "graph { a [color=] }", "graph { ; }"} {
+		if _, err := ReadDOT(strings.NewReader(source)); err == nil {
+			t.Fatalf("ReadDOT(%q): expected a syntax error", source)
+		}
+	}
+	if read, err := ReadDOT(strings.NewReader(`graph { a -- b [weight=3] }`)); err != nil || read.edges[0].weight != 1 {
+		t.Fatalf("ReadDOT(): expected the layout weight to be ignored, got %v (%v).", read.edges, err)
+	}
+}
+
+// TestDOTWeights:
+// Verify negative and fractional weights are written out of the Graphviz weight attribute
+func TestDOTWeights(t *testing.T) {
+	g := Graph{}
+	g.AddVertex(nil)
+	g.AddVertex(nil)
+	g.AddWeightedEdge(0, 1, -2.5, nil)
+	var b bytes.Buffer
+	WriteDOT(&b, g, DOTOptions{})
+	if strings.Contains(b.String(), "weight") || !strings.Contains(b.String(), "0 -- 1 [cost=-2.5];") {
+		t.Fatalf("WriteDOT(): expected the weight in the cost attribute, got\n%s", b.String())
+	}
+	read, err := ReadDOT(&b)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if e, _ := read.GetEdge(0); e.Weight() != -2.5 {
+		t.Fatalf("ReadDOT(): expected weight -2.5, got %v.", e.Weight())
+	}
+}
@@ -1,7 +1,36 @@
 package graph
 
-type edge struct {
+// an edge of a graph
+// edges are created by Graph.AddEdge and retrieved by Graph.GetEdge
+type Edge struct {
 	id        int
-	endpoints []int
+	endpoints [2]int
+	weight    float64
+	removed   bool
 	Data      interface{}
 }
+
+// returns the id of this edge
+func (e *Edge) ID() int {
+	return e.id
+}
+
+// returns the ids of the vertexes joined by this edge
+// for directed graphs the edge goes from the first vertex to the second
+func (e *Edge) Endpoints() (int, int) {
+	return e.endpoints[0], e.endpoints[1]
+}
+
+// returns the endpoint of this edge opposite to vertexIndex
+// for self-loops vertexIndex itself is returned
+func (e *Edge) Other(vertexIndex int) int {
+	if e.endpoints[0] == vertexIndex {
+		return e.endpoints[1]
+	}
+	return e.endpoints[0]
+}
+
+// returns true if this edge joins a vertex with itself
+func (e *Edge) IsLoop() bool {
+	return e.endpoints[0] == e.endpoints[1]
+}
@@ -0,0 +1,247 @@
+package graph
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// writes g to w as a tab separated edge list
+// the list starts with the comment lines "# directed" or "# undirected" and
+// "# order <Order()>", followed by one line per edge: id, source, target, weight and
+// the Data encoded by codec. vertex Data is not stored. removed edges are skipped
+// and removed vertexes are restored as isolated vertexes
+func WriteEdgeList(w io.Writer, g Interface, codec PayloadCodec) error {
+	if codec == nil {
+		codec = StringCodec{}
+	}
+	kind := "undirected"
+	if g.IsDirected() {
+		kind = "directed"
+	}
+	if _, err := fmt.Fprintf(w, "# %s\n# order %d\n", kind, g.Order()); err != nil {
+		return err
+	}
+	writer := csv.NewWriter(w)
+	writer.Comma = '\t'
+	for i := 0; i < g.Size(); i++ {
+		vi, vj, err := g.Endpoints(i)
+		if err != nil {
+			// removed edge
+			continue
+		}
+		weight, _ := g.Weight(i)
+		text := ""
+		if data, _ := g.EdgeData(i); data != nil {
+			if text, err = codec.Encode(data); err != nil {
+				return fmt.Errorf("edge: %d: %v", i, err)
+			}
+		}
+		record := []string{
+			strconv.Itoa(i),
+			strconv.Itoa(vi),
+			strconv.Itoa(vj),
+			strconv.FormatFloat(weight, 'g', -1, 64),
+			text,
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// reads an edge list written by WriteEdgeList
+// lines holding only "source target" or "source target weight" are accepted as well,
+// in which case edges are numbered in order of appearance.
+// the "# directed" and "# order" comments are only read before the first edge,
+// other lines starting with # are ignored.
+// without an "# order" comment the order is 1 + the highest vertex id found
+func ReadEdgeList(r io.Reader, codec PayloadCodec) (Graph, error) {
+	if codec == nil {
+		codec = StringCodec{}
+	}
+	directed, order, headerLines := false, 0, 0
+	input := bufio.NewReader(r)
+	for {
+		if next, err := input.Peek(1); err != nil || next[0] != '#' {
+			break
+		}
+		line, err := input.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return Graph{}, err
+		}
+		headerLines++
+		fields := strings.Fields(strings.TrimPrefix(line, "#"))
+		switch {
+		case len(fields) == 1 && fields[0] == "directed":
+			directed = true
+		case len(fields) == 2 && fields[0] == "order":
+			if order, err = strconv.Atoi(fields[1]); err != nil || order < 0 {
+				return Graph{}, fmt.Errorf("edge list: invalid order %q", fields[1])
+			}
+		}
+	}
+	reader := csv.NewReader(input)
+	reader.Comma = '\t'
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	b := newGraphBuilder(directed)
+	for i := 0; ; i++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return Graph{}, err
+		}
+		line, _ := reader.FieldPos(0)
+		line += headerLines
+		if len(record) == 1 {
+			// whitespace separated "source target [weight]"
+			record = strings.Fields(record[0])
+			if len(record) > 3 {
+				return Graph{}, fmt.Errorf("edge list: line %d: too many fields", line)
+			}
+			record = append([]string{strconv.Itoa(i)}, record...)
+		} else if len(record) < 4 {
+			record = append([]string{strconv.Itoa(i)}, record...)
+		}
+		if len(record) < 3 {
+			return Graph{}, fmt.Errorf("edge list: line %d: expected source and target", line)
+		}
+		numbers := make([]int, 3)
+		for k := range numbers {
+			if numbers[k], err = strconv.Atoi(strings.TrimSpace(record[k])); err != nil {
+				return Graph{}, fmt.Errorf("edge list: line %d: invalid id %q", line, record[k])
+			}
+		}
+		weight := 1.0
+		if len(record) > 3 {
+			if weight, err = strconv.ParseFloat(strings.TrimSpace(record[3]), 64); err != nil {
+				return Graph{}, fmt.Errorf("edge list: line %d: invalid weight %q", line, record[3])
+			}
+		}
+		var data interface{}
+		if len(record) > 4 && record[4] != "" {
+			if data, err = codec.Decode(record[4]); err != nil {
+				return Graph{}, fmt.Errorf("edge list: line %d: %v", line, err)
+			}
+		}
+		for _, v := range numbers[1:] {
+			if v >= order {
+				order = v + 1
+			}
+		}
+		if err := b.edge(numbers[0], numbers[1], numbers[2], weight, data); err != nil {
+			return Graph{}, err
+		}
+	}
+	// vertexes are not stored, so their number is bounded by the edges read
+	if err := testIDBound("vertex", order, 2*len(b.edges)); err != nil {
+		return Graph{}, fmt.Errorf("edge list: %v", err)
+	}
+	for v := 0; v < order; v++ {
+		b.vertex(v, nil)
+	}
+	return b.build()
+}
+
+// writes g to w as a comma separated adjacency matrix
+// the first row and column hold the Data of the vertexes encoded by codec,
+// cell (i, j) holds the weight of the edge from vertex i to vertex j, or is empty
+// if there is none. undirected graphs produce a symmetric matrix.
+// the matrix cannot store edge ids, edge Data, parallel edges or removed vertexes,
+// so an error is returned for graphs with parallel edges or removed vertexes
+func WriteAdjacencyMatrix(w io.Writer, g Interface, codec PayloadCodec) error {
+	if codec == nil {
+		codec = StringCodec{}
+	}
+	if vertexCount(g) < g.Order() {
+		return fmt.Errorf("adjacency matrix: graph has removed vertexes, call Compact first")
+	}
+	n := g.Order()
+	rows := make([][]string, n+1)
+	rows[0] = make([]string, n+1)
+	for i := 0; i < n; i++ {
+		data, _ := g.VertexData(i)
+		label, err := codec.Encode(data)
+		if err != nil {
+			return fmt.Errorf("vertex: %d: %v", i, err)
+		}
+		rows[0][i+1] = label
+		rows[i+1] = make([]string, n+1)
+		rows[i+1][0] = label
+	}
+	for i := 0; i < g.Size(); i++ {
+		vi, vj, err := g.Endpoints(i)
+		if err != nil {
+			// removed edge
+			continue
+		}
+		if rows[vi+1][vj+1] != "" {
+			return fmt.Errorf("adjacency matrix: parallel edges between vertex: %d and vertex: %d", vi, vj)
+		}
+		weight, _ := g.Weight(i)
+		rows[vi+1][vj+1] = strconv.FormatFloat(weight, 'g', -1, 64)
+		if !g.IsDirected() {
+			rows[vj+1][vi+1] = rows[vi+1][vj+1]
+		}
+	}
+	writer := csv.NewWriter(w)
+	if err := writer.WriteAll(rows); err != nil {
+		return err
+	}
+	return writer.Error()
+}
+
+// reads an adjacency matrix written by WriteAdjacencyMatrix
+// vertexes keep their ids and Data, edges are numbered in row-major order
+// for undirected graphs only the upper triangle of the matrix is read
+func ReadAdjacencyMatrix(r io.Reader, directed bool, codec PayloadCodec) (Graph, error) {
+	if codec == nil {
+		codec = StringCodec{}
+	}
+	rows, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return Graph{}, err
+	}
+	g := NewGraph()
+	g.directed = directed
+	if len(rows) == 0 {
+		return g, nil
+	}
+	n := len(rows) - 1
+	for i := 1; i <= n; i++ {
+		if len(rows[0]) != n+1 || len(rows[i]) != n+1 {
+			return Graph{}, fmt.Errorf("adjacency matrix: expected %d columns in every row", n+1)
+		}
+		data, err := codec.Decode(rows[0][i])
+		if err != nil {
+			return Graph{}, fmt.Errorf("vertex: %d: %v", i-1, err)
+		}
+		g.AddVertex(data)
+	}
+	for i := 1; i <= n; i++ {
+		first := 1
+		if !directed {
+			first = i
+		}
+		for j := first; j <= n; j++ {
+			cell := strings.TrimSpace(rows[i][j])
+			if cell == "" {
+				continue
+			}
+			weight, err := strconv.ParseFloat(cell, 64)
+			if err != nil {
+				return Graph{}, fmt.Errorf("adjacency matrix: invalid weight %q in row %d", cell, i)
+			}
+			g.AddWeightedEdge(i-1, j-1, weight, nil)
+		}
+	}
+	return g, nil
+}
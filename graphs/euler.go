@@ -0,0 +1,138 @@
+package graph
+
+import "fmt"
+
+// returned when a graph has no Eulerian circuit or path
+type ErrNotEulerian struct {
+	Reason string
+}
+
+func (err ErrNotEulerian) Error() string {
+	return fmt.Sprintf("graph is not eulerian: %s", err.Reason)
+}
+
+// returns a closed path that uses every edge of the graph exactly once
+// or an ErrNotEulerian error describing why no such path exists
+func EulerCircuit(g Interface) (Path, error) {
+	start, end, err := eulerEndpoints(g)
+	if err != nil {
+		return Path{}, err
+	}
+	if start != end {
+		return Path{}, ErrNotEulerian{fmt.Sprintf("vertexes: %d and %d are unbalanced, only an open walk exists", start, end)}
+	}
+	return euler(g, start)
+}
+
+// returns a path that uses every edge of the graph exactly once
+// the path is closed whenever an Eulerian circuit exists
+// or an ErrNotEulerian error describing why no such path exists
+func EulerPath(g Interface) (Path, error) {
+	start, _, err := eulerEndpoints(g)
+	if err != nil {
+		return Path{}, err
+	}
+	return euler(g, start)
+}
+
+// applies Hierholzer's algorithm: a walk is traced from start and every sub-tour
+// found at a vertex with unused edges is spliced into the walk at that vertex
+func euler(g Interface, start int) (Path, error) {
+	path, err := NewPath(g, start)
+	if err != nil {
+		return path, err
+	}
+	t := newTracer(g)
+	type frame struct {
+		start int
+		steps []Adjacency
+		pos   int
+	}
+	steps := make([]Adjacency, 0, g.Size())
+	frames := []frame{{start, t.trace(start), 0}}
+	for len(frames) > 0 {
+		f := &frames[len(frames)-1]
+		vertex := f.start
+		if f.pos > 0 {
+			vertex = f.steps[f.pos-1].Endpoint
+		}
+		if t.hasUnvisited(vertex) {
+			// the sub-tour is closed, so f resumes at vertex once it is spliced
+			frames = append(frames, frame{vertex, t.trace(vertex), 0})
+			continue
+		}
+		if f.pos == len(f.steps) {
+			frames = frames[:len(frames)-1]
+			continue
+		}
+		steps = append(steps, f.steps[f.pos])
+		f.pos++
+	}
+	return path.growSteps(steps)
+}
+
+// checks degree balance and connectivity of g
+// returns the vertexes where an Eulerian path must start and end,
+// which are the same vertex if an Eulerian circuit exists
+func eulerEndpoints(g Interface) (int, int, error) {
+	if vertexCount(g) == 0 {
+		return -1, -1, ErrEmptyGraph
+	}
+	start, end := -1, -1
+	// the walk starts at the first vertex with edges, or at the first vertex if there are none
+	first := -1
+	for i := g.Order() - 1; i >= 0; i-- {
+		if degree(g, i) > 0 || first == -1 && vertexExists(g, i) {
+			first = i
+		}
+	}
+	if g.IsDirected() {
+		for i := 0; i < g.Order(); i++ {
+			if !vertexExists(g, i) {
+				continue
+			}
+			out, in := len(adjacencies(g, i)), len(inAdjacencies(g, i))
+			switch balance := out - in; {
+			case balance == 0:
+			case balance == 1 && start == -1:
+				start = i
+			case balance == -1 && end == -1:
+				end = i
+			default:
+				return -1, -1, ErrNotEulerian{fmt.Sprintf("vertex: %d has in-degree %d and out-degree %d", i, in, out)}
+			}
+		}
+	} else {
+		odd := make([]int, 0)
+		for i := 0; i < g.Order(); i++ {
+			if degree(g, i)%2 == 1 {
+				odd = append(odd, i)
+			}
+		}
+		if len(odd) > 2 {
+			return -1, -1, ErrNotEulerian{fmt.Sprintf("%d vertexes have odd degree: %v", len(odd), odd)}
+		}
+		if len(odd) == 2 {
+			start, end = odd[0], odd[1]
+		}
+	}
+	if start == -1 {
+		start, end = first, first
+	}
+	if v := unreachedEdgeVertex(g, start); v != -1 {
+		return -1, -1, ErrNotEulerian{fmt.Sprintf("edges at vertex: %d are not connected to edges at vertex: %d", v, start)}
+	}
+	return start, end, nil
+}
+
+// returns a vertex with incident edges that is not connected to start, or -1 if there is none
+// edge directions are ignored
+func unreachedEdgeVertex(g Interface, start int) int {
+	components, _ := ConnectedComponents(g)
+	for i := 0; i < g.Order(); i++ {
+		if components[i] != components[start] && degree(g, i) > 0 {
+			return i
+		}
+	}
+	return -1
+}
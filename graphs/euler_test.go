@@ -0,0 +1,83 @@
+package graph
+
+import "testing"
+
+// verifies path is an Eulerian walk of g
+func checkEulerian(t *testing.T, g Graph, path Path, closed bool) {
+	if len(path.out) != g.Size() {
+		t.Fatalf("expected a walk of %d edges, got %d.", g.Size(), len(path.out))
+	}
+	used := make([]bool, g.Size())
+	for _, o := range path.out {
+		if used[o.Edge] {
+			t.Fatalf("edge: %d used twice.", o.Edge)
+		}
+		used[o.Edge] = true
+	}
+	if closed && path.GetLastVertex() != path.start {
+		t.Fatalf("expected a closed walk from vertex: %d, got one ending at vertex: %d.", path.start, path.GetLastVertex())
+	}
+}
+
+// TestEulerCircuit:
+// Verify sub-tours are spliced into a complete circuit
+func TestEulerCircuit(t *testing.T) {
+	g := Graph{}
+	for i := 0; i < 5; i++ {
+		g.AddVertex(i)
+	}
+	// two triangles sharing vertex 0, and a self-loop at vertex 3
+	g.AddEdge(0, 1, nil)
+	g.AddEdge(1, 2, nil)
+	g.AddEdge(2, 0, nil)
+	g.AddEdge(0, 3, nil)
+	g.AddEdge(3, 4, nil)
+	g.AddEdge(4, 0, nil)
+	g.AddEdge(3, 3, nil)
+	trace, _ := Trace(g, 0)
+	if len(trace.out) == g.Size() {
+		t.Fatalf("Trace(g, 0): expected an incomplete walk")
+	}
+	path, err := EulerCircuit(g)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	checkEulerian(t, g, path, true)
+	g.AddEdge(1, 3, nil)
+	if _, err := EulerCircuit(g); err == nil {
+		t.Fatalf("EulerCircuit(): expected an error for a graph with odd vertexes")
+	}
+	path, err = EulerPath(g)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	checkEulerian(t, g, path, false)
+	g.AddEdge(2, 4, nil)
+	if _, err := EulerPath(g); err == nil {
+		t.Fatalf("EulerPath(): expected an error for a graph with four odd vertexes")
+	}
+}
+
+// TestEulerDirected:
+// Verify balance and connectivity checks of directed graphs
+func TestEulerDirected(t *testing.T) {
+	g := NewDigraph()
+	for i := 0; i < 6; i++ {
+		g.AddVertex(i)
+	}
+	g.AddEdge(0, 1, nil)
+	g.AddEdge(1, 2, nil)
+	g.AddEdge(2, 0, nil)
+	g.AddEdge(1, 3, nil)
+	g.AddEdge(3, 1, nil)
+	path, err := EulerCircuit(g)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	checkEulerian(t, g, path, true)
+	g.AddEdge(4, 5, nil)
+	g.AddEdge(5, 4, nil)
+	if _, err := EulerCircuit(g); err == nil {
+		t.Fatalf("EulerCircuit(): expected an error for a disconnected graph")
+	}
+}
@@ -0,0 +1,216 @@
+package graph
+
+import (
+	"fmt"
+	"math"
+)
+
+// residual capacities below this value are considered exhausted
+const flowEpsilon = 1e-12
+
+// a maximum flow between two vertexes as computed by EdmondsKarp or Dinic
+type Flow struct {
+	// total flow leaving the source
+	Value float64
+	// flow through every edge, indexed by edge id
+	// for undirected graphs a negative value is a flow from the second endpoint to the first
+	Edges []float64
+	// SourceSide[v] is true if v is on the source side of a minimum s-t cut
+	SourceSide []bool
+	// ids of the edges crossing the minimum cut, their capacities add up to Value
+	Cut []int
+}
+
+// the residual network of a graph
+// arcs are stored in pairs, the reverse of arc a is a^1
+type residual struct {
+	g        Interface
+	head     []int
+	capacity []float64
+	arcs     [][]int
+}
+
+func newResidual(g Interface, source, sink int, capacity WeightFunc) (*residual, error) {
+	if err := testVertex(g, source, sink); err != nil {
+		return nil, err
+	}
+	if source == sink {
+		return nil, fmt.Errorf("source and sink are the same vertex: %d", source)
+	}
+	capacity = weightOrDefault(capacity)
+	r := &residual{
+		g:        g,
+		head:     make([]int, 2*g.Size()),
+		capacity: make([]float64, 2*g.Size()),
+		arcs:     make([][]int, g.Order()),
+	}
+	for id := 0; id < g.Size(); id++ {
+		e, err := getEdge(g, id)
+		if err != nil {
+			continue
+		}
+		c := capacity(e)
+		if c < 0 {
+			return nil, ErrNegativeWeight{e.id, c}
+		}
+		u, v := e.Endpoints()
+		r.head[2*e.id], r.head[2*e.id+1] = v, u
+		r.capacity[2*e.id] = c
+		if !g.IsDirected() {
+			r.capacity[2*e.id+1] = c
+		}
+		if u != v {
+			r.arcs[u] = append(r.arcs[u], 2*e.id)
+			r.arcs[v] = append(r.arcs[v], 2*e.id+1)
+		}
+	}
+	return r, nil
+}
+
+// pushes amount units of flow through arc
+func (r *residual) push(arc int, amount float64) {
+	r.capacity[arc] -= amount
+	r.capacity[arc^1] += amount
+}
+
+// returns the vertexes reachable from source through arcs with residual capacity
+// and their distance in arcs, -1 for unreachable vertexes
+func (r *residual) levels(source int) []int {
+	level := make([]int, len(r.arcs))
+	for v := range level {
+		level[v] = -1
+	}
+	level[source] = 0
+	queue := []int{source}
+	for len(queue) > 0 {
+		u := queue[0]
+		queue = queue[1:]
+		for _, a := range r.arcs[u] {
+			if v := r.head[a]; level[v] == -1 && r.capacity[a] > flowEpsilon {
+				level[v] = level[u] + 1
+				queue = append(queue, v)
+			}
+		}
+	}
+	return level
+}
+
+// builds the resulting Flow once no augmenting path is left
+func (r *residual) flow(source int, capacity WeightFunc) Flow {
+	capacity = weightOrDefault(capacity)
+	f := Flow{
+		Edges:      make([]float64, r.g.Size()),
+		SourceSide: make([]bool, r.g.Order()),
+		Cut:        make([]int, 0),
+	}
+	for v, level := range r.levels(source) {
+		f.SourceSide[v] = level != -1
+	}
+	for id := 0; id < r.g.Size(); id++ {
+		e, err := getEdge(r.g, id)
+		if err != nil {
+			continue
+		}
+		u, v := e.Endpoints()
+		if u == v {
+			continue
+		}
+		f.Edges[e.id] = capacity(e) - r.capacity[2*e.id]
+		if u == source {
+			f.Value += f.Edges[e.id]
+		} else if v == source {
+			f.Value -= f.Edges[e.id]
+		}
+		if f.SourceSide[u] && !f.SourceSide[v] || !r.g.IsDirected() && f.SourceSide[v] && !f.SourceSide[u] {
+			f.Cut = append(f.Cut, e.id)
+		}
+	}
+	return f
+}
+
+// computes a maximum flow from source to sink using the Edmonds-Karp algorithm,
+// augmenting along shortest paths of the residual network
+// edge capacities are given by capacity, or by the edge weights if capacity is nil
+func EdmondsKarp(g Interface, source, sink int, capacity WeightFunc) (Flow, error) {
+	r, err := newResidual(g, source, sink, capacity)
+	if err != nil {
+		return Flow{}, err
+	}
+	via := make([]int, g.Order())
+	for {
+		for v := range via {
+			via[v] = -1
+		}
+		queue := []int{source}
+		for len(queue) > 0 && via[sink] == -1 {
+			u := queue[0]
+			queue = queue[1:]
+			for _, a := range r.arcs[u] {
+				if v := r.head[a]; v != source && via[v] == -1 && r.capacity[a] > flowEpsilon {
+					via[v] = a
+					queue = append(queue, v)
+				}
+			}
+		}
+		if via[sink] == -1 {
+			return r.flow(source, capacity), nil
+		}
+		amount := math.Inf(1)
+		for v := sink; v != source; v = r.head[via[v]^1] {
+			amount = math.Min(amount, r.capacity[via[v]])
+		}
+		if math.IsInf(amount, 1) {
+			return Flow{}, fmt.Errorf("unbounded flow from vertex: %d to vertex: %d", source, sink)
+		}
+		for v := sink; v != source; v = r.head[via[v]^1] {
+			r.push(via[v], amount)
+		}
+	}
+}
+
+// computes a maximum flow from source to sink using Dinic's algorithm,
+// saturating blocking flows of the level graph of the residual network
+// edge capacities are given by capacity, or by the edge weights if capacity is nil
+func Dinic(g Interface, source, sink int, capacity WeightFunc) (Flow, error) {
+	r, err := newResidual(g, source, sink, capacity)
+	if err != nil {
+		return Flow{}, err
+	}
+	next := make([]int, g.Order())
+	var augment func(u int, limit float64, level []int) float64
+	augment = func(u int, limit float64, level []int) float64 {
+		if u == sink {
+			return limit
+		}
+		for ; next[u] < len(r.arcs[u]); next[u]++ {
+			a := r.arcs[u][next[u]]
+			v := r.head[a]
+			if r.capacity[a] <= flowEpsilon || level[v] != level[u]+1 {
+				continue
+			}
+			if pushed := augment(v, math.Min(limit, r.capacity[a]), level); pushed > flowEpsilon {
+				r.push(a, pushed)
+				return pushed
+			}
+		}
+		return 0
+	}
+	for {
+		level := r.levels(source)
+		if level[sink] == -1 {
+			return r.flow(source, capacity), nil
+		}
+		for v := range next {
+			next[v] = 0
+		}
+		for {
+			pushed := augment(source, math.Inf(1), level)
+			if pushed <= flowEpsilon {
+				break
+			}
+			if math.IsInf(pushed, 1) {
+				return Flow{}, fmt.Errorf("unbounded flow from vertex: %d to vertex: %d", source, sink)
+			}
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package graph
+
+import "testing"
+
+// TestMaxFlow:
+// Verify flow value, conservation and minimum cut
+func TestMaxFlow(t *testing.T) {
+	g := NewDigraph()
+	for i := 0; i < 6; i++ {
+		g.AddVertex(i)
+	}
+	g.AddWeightedEdge(0, 1, 16, nil)
+	g.AddWeightedEdge(0, 2, 13, nil)
+	g.AddWeightedEdge(2, 1, 4, nil)
+	g.AddWeightedEdge(1, 3, 12, nil)
+	g.AddWeightedEdge(3, 2, 9, nil)
+	g.AddWeightedEdge(2, 4, 14, nil)
+	g.AddWeightedEdge(4, 3, 7, nil)
+	g.AddWeightedEdge(3, 5, 20, nil)
+	g.AddWeightedEdge(4, 5, 4, nil)
+	for name, maxFlow := range map[string]func(Interface, int, int, WeightFunc) (Flow, error){"EdmondsKarp": EdmondsKarp, "Dinic": Dinic} {
+		flow, err := maxFlow(g, 0, 5, nil)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		if flow.Value != 23 {
+			t.Fatalf("%s(): expected flow 23, got %v.", name, flow.Value)
+		}
+		balance := make([]float64, g.Order())
+		for e, f := range flow.Edges {
+			if f < 0 || f > g.edges[e].weight {
+				t.Fatalf("%s(): flow %v exceeds the capacity of edge: %d.", name, f, e)
+			}
+			balance[g.edges[e].endpoints[0]] -= f
+			balance[g.edges[e].endpoints[1]] += f
+		}
+		for v := 1; v < 5; v++ {
+			if balance[v] != 0 {
+				t.Fatalf("%s(): flow is not conserved at vertex: %d.", name, v)
+			}
+		}
+		cut := 0.0
+		for _, e := range flow.Cut {
+			cut += g.edges[e].weight
+		}
+		if cut != 23 || !flow.SourceSide[0] || flow.SourceSide[5] {
+			t.Fatalf("%s(): expected a cut of capacity 23, got %v: %v.", name, cut, flow.SourceSide)
+		}
+	}
+}
+
+// TestUndirectedMaxFlow:
+// Verify undirected edges carry flow in either direction
+func TestUndirectedMaxFlow(t *testing.T) {
+	g := Graph{}
+	for i := 0; i < 4; i++ {
+		g.AddVertex(i)
+	}
+	g.AddWeightedEdge(0, 1, 3, nil)
+	g.AddWeightedEdge(2, 0, 2, nil)
+	g.AddWeightedEdge(1, 2, 5, nil)
+	g.AddWeightedEdge(3, 1, 1, nil)
+	g.AddWeightedEdge(2, 3, 3, nil)
+	flow, err := Dinic(g, 0, 3, nil)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if flow.Value != 4 || flow.Edges[1] != -2 {
+		t.Fatalf("Dinic(): expected flow 4 with -2 through edge: 1, got %v and %v.", flow.Value, flow.Edges[1])
+	}
+}
@@ -0,0 +1,251 @@
+package graph
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// generators of well known graphs, useful as test fixtures and benchmark inputs
+// all of them return undirected graphs whose vertexes and edges hold nil Data
+// and have weight 1. random generators are seeded, so the same seed gives the same graph
+
+// returns an undirected graph with n vertexes and no edges
+func emptyGraph(n int) Graph {
+	g := NewGraph()
+	for i := 0; i < n; i++ {
+		g.AddVertex(nil)
+	}
+	return g
+}
+
+// returns the complete graph K(n): every pair of its n vertexes is joined by an edge
+func CompleteGraph(n int) Graph {
+	g := emptyGraph(n)
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			g.AddEdge(i, j, nil)
+		}
+	}
+	return g
+}
+
+// returns the path graph P(n): n vertexes joined by the edges i, i+1
+func PathGraph(n int) Graph {
+	g := emptyGraph(n)
+	for i := 0; i+1 < n; i++ {
+		g.AddEdge(i, i+1, nil)
+	}
+	return g
+}
+
+// returns the cycle graph C(n): the path graph P(n) plus an edge from its last vertex to its first
+// for n = 1 the cycle is a self-loop and for n = 2 a pair of parallel edges
+func CycleGraph(n int) Graph {
+	g := PathGraph(n)
+	if n > 0 {
+		g.AddEdge(n-1, 0, nil)
+	}
+	return g
+}
+
+// returns the star graph S(n): vertex 0 joined to each of n other vertexes
+func StarGraph(n int) Graph {
+	g := emptyGraph(n + 1)
+	for i := 1; i <= n; i++ {
+		g.AddEdge(0, i, nil)
+	}
+	return g
+}
+
+// returns the grid graph of rows x columns vertexes, each joined to its horizontal
+// and vertical neighbors. the vertex at row r and column c has id r*columns + c
+func GridGraph(rows, columns int) Graph {
+	if rows <= 0 || columns <= 0 {
+		return NewGraph()
+	}
+	g := emptyGraph(rows * columns)
+	for r := 0; r < rows; r++ {
+		for c := 0; c < columns; c++ {
+			if c+1 < columns {
+				g.AddEdge(r*columns+c, r*columns+c+1, nil)
+			}
+			if r+1 < rows {
+				g.AddEdge(r*columns+c, (r+1)*columns+c, nil)
+			}
+		}
+	}
+	return g
+}
+
+// returns the Erdős–Rényi random graph G(n, p): each pair of its n vertexes
+// is joined by an edge with probability p
+func ErdosRenyi(n int, p float64, seed int64) (Graph, error) {
+	if p < 0 || p > 1 {
+		return Graph{}, fmt.Errorf("erdos-renyi: probability %v is not in [0, 1]", p)
+	}
+	random := rand.New(rand.NewSource(seed))
+	g := emptyGraph(n)
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			if random.Float64() < p {
+				g.AddEdge(i, j, nil)
+			}
+		}
+	}
+	return g, nil
+}
+
+// maximum number of times RandomRegular starts over after reaching a dead end
+const maxRegularAttempts = 100
+
+// returns a random d-regular simple graph with n vertexes: every vertex has degree d
+// and there are no self-loops nor parallel edges
+// n*d must be even and d < n
+func RandomRegular(n, d int, seed int64) (Graph, error) {
+	if d < 0 || n < 0 || d >= n && n > 0 || n*d%2 != 0 {
+		return Graph{}, fmt.Errorf("random regular: there is no %d-regular simple graph with %d vertexes", d, n)
+	}
+	random := rand.New(rand.NewSource(seed))
+	for attempt := 0; attempt < maxRegularAttempts; attempt++ {
+		if edges, ok := regularPairing(n, d, random); ok {
+			g := emptyGraph(n)
+			for _, e := range edges {
+				g.AddEdge(e[0], e[1], nil)
+			}
+			return g, nil
+		}
+	}
+	return Graph{}, fmt.Errorf("random regular: no %d-regular graph with %d vertexes found after %d attempts", d, n, maxRegularAttempts)
+}
+
+// pairs d copies of every vertex at random, rejecting pairs that would make
+// a self-loop or a parallel edge. returns false if the pairing reaches a dead end
+func regularPairing(n, d int, random *rand.Rand) ([][2]int, bool) {
+	points := make([]int, 0, n*d)
+	for v := 0; v < n; v++ {
+		for i := 0; i < d; i++ {
+			points = append(points, v)
+		}
+	}
+	joined := make(map[[2]int]bool)
+	edges := make([][2]int, 0, n*d/2)
+	for len(points) > 0 {
+		found := false
+		// a few random draws are enough unless the pairing is close to a dead end
+		for try := 0; try < 10*len(points) && !found; try++ {
+			i, j := random.Intn(len(points)), random.Intn(len(points))
+			vi, vj := points[i], points[j]
+			if vi > vj {
+				vi, vj = vj, vi
+			}
+			if vi == vj || joined[[2]int{vi, vj}] {
+				continue
+			}
+			joined[[2]int{vi, vj}] = true
+			edges = append(edges, [2]int{vi, vj})
+			// removes both points, the highest index first
+			if i < j {
+				i, j = j, i
+			}
+			points[i] = points[len(points)-1]
+			points = points[:len(points)-1]
+			points[j] = points[len(points)-1]
+			points = points[:len(points)-1]
+			found = true
+		}
+		if !found {
+			return nil, false
+		}
+	}
+	return edges, true
+}
+
+// returns a Barabási–Albert preferential attachment graph with n vertexes
+// it starts from the complete graph K(m+1) and joins every other vertex to m distinct
+// existing vertexes, chosen with probability proportional to their degree
+// 1 <= m < n must hold
+func BarabasiAlbert(n, m int, seed int64) (Graph, error) {
+	if m < 1 || m >= n {
+		return Graph{}, fmt.Errorf("barabasi-albert: expected 1 <= m < n, got m = %d and n = %d", m, n)
+	}
+	random := rand.New(rand.NewSource(seed))
+	g := CompleteGraph(m + 1)
+	// every vertex appears once per incident edge, so a uniform pick is proportional to degree
+	endpoints := make([]int, 0, 2*(m*(m+1)/2+(n-m-1)*m))
+	for _, e := range g.edges {
+		endpoints = append(endpoints, e.endpoints[0], e.endpoints[1])
+	}
+	for v := m + 1; v < n; v++ {
+		g.AddVertex(nil)
+		targets := make(map[int]bool, m)
+		chosen := make([]int, 0, m)
+		for len(chosen) < m {
+			t := endpoints[random.Intn(len(endpoints))]
+			if !targets[t] {
+				targets[t] = true
+				chosen = append(chosen, t)
+			}
+		}
+		for _, t := range chosen {
+			g.AddEdge(v, t, nil)
+			endpoints = append(endpoints, v, t)
+		}
+	}
+	return g, nil
+}
+
+// returns a Watts–Strogatz small world graph with n vertexes
+// it starts from a ring where every vertex is joined to its k nearest vertexes, k/2 on each side,
+// then moves the far endpoint of every edge to a random vertex with probability beta,
+// avoiding self-loops and parallel edges
+// k must be even and 0 <= k < n, beta must be in [0, 1]
+func WattsStrogatz(n, k int, beta float64, seed int64) (Graph, error) {
+	if k < 0 || k%2 != 0 || k >= n && k > 0 {
+		return Graph{}, fmt.Errorf("watts-strogatz: expected an even k < n, got k = %d and n = %d", k, n)
+	}
+	if beta < 0 || beta > 1 {
+		return Graph{}, fmt.Errorf("watts-strogatz: probability %v is not in [0, 1]", beta)
+	}
+	random := rand.New(rand.NewSource(seed))
+	key := func(vi, vj int) [2]int {
+		if vi > vj {
+			return [2]int{vj, vi}
+		}
+		return [2]int{vi, vj}
+	}
+	edges := make([][2]int, 0, n*k/2)
+	joined := make(map[[2]int]bool, n*k/2)
+	degree := make([]int, n)
+	for j := 1; j <= k/2; j++ {
+		for i := 0; i < n; i++ {
+			e := [2]int{i, (i + j) % n}
+			edges = append(edges, e)
+			joined[key(e[0], e[1])] = true
+			degree[e[0]]++
+			degree[e[1]]++
+		}
+	}
+	for i, e := range edges {
+		if random.Float64() >= beta {
+			continue
+		}
+		// a vertex joined to every other one keeps its edges
+		if degree[e[0]] >= n-1 {
+			continue
+		}
+		w := random.Intn(n)
+		for w == e[0] || joined[key(e[0], w)] {
+			w = random.Intn(n)
+		}
+		delete(joined, key(e[0], e[1]))
+		joined[key(e[0], w)] = true
+		degree[e[1]]--
+		degree[w]++
+		edges[i] = [2]int{e[0], w}
+	}
+	g := emptyGraph(n)
+	for _, e := range edges {
+		g.AddEdge(e[0], e[1], nil)
+	}
+	return g, nil
+}
@@ -0,0 +1,126 @@
+package graph
+
+import (
+	"fmt"
+	"testing"
+)
+
+// returns true if g has no self-loops nor parallel edges
+func isSimpleGraph(g Graph) bool {
+	joined := make(map[[2]int]bool)
+	for _, e := range g.edges {
+		vi, vj := e.Endpoints()
+		if vi > vj {
+			vi, vj = vj, vi
+		}
+		if vi == vj || joined[[2]int{vi, vj}] {
+			return false
+		}
+		joined[[2]int{vi, vj}] = true
+	}
+	return true
+}
+
+// TestStructuredGenerators:
+// Verify the order, size and degrees of the structured graphs
+func TestStructuredGenerators(t *testing.T) {
+	cases := []struct {
+		name        string
+		g           Graph
+		order, size int
+		degrees     string
+	}{
+		{"CompleteGraph(4)", CompleteGraph(4), 4, 6, "[3 3 3 3]"},
+		{"PathGraph(4)", PathGraph(4), 4, 3, "[1 2 2 1]"},
+		{"CycleGraph(4)", CycleGraph(4), 4, 4, "[2 2 2 2]"},
+		{"CycleGraph(1)", CycleGraph(1), 1, 1, "[2]"},
+		{"StarGraph(3)", StarGraph(3), 4, 3, "[3 1 1 1]"},
+		{"GridGraph(2, 3)", GridGraph(2, 3), 6, 7, "[2 3 2 2 3 2]"},
+		{"GridGraph(0, 3)", GridGraph(0, 3), 0, 0, "[]"},
+		{"CompleteGraph(0)", CompleteGraph(0), 0, 0, "[]"},
+	}
+	for _, c := range cases {
+		degrees := make([]int, c.g.Order())
+		for v := range degrees {
+			degrees[v] = degree(c.g, v)
+		}
+		if c.g.Order() != c.order || c.g.Size() != c.size || fmt.Sprint(degrees) != c.degrees {
+			t.Fatalf("%s: expected %d vertexes, %d edges and degrees %s, got %d, %d and %v.",
+				c.name, c.order, c.size, c.degrees, c.g.Order(), c.g.Size(), degrees)
+		}
+	}
+}
+
+// TestRandomGenerators:
+// Verify the random graphs have the expected structure and are reproducible from their seed
+func TestRandomGenerators(t *testing.T) {
+	generators := map[string]func(seed int64) (Graph, error){
+		"ErdosRenyi":     func(seed int64) (Graph, error) { return ErdosRenyi(50, 0.1, seed) },
+		"RandomRegular":  func(seed int64) (Graph, error) { return RandomRegular(50, 3, seed) },
+		"BarabasiAlbert": func(seed int64) (Graph, error) { return BarabasiAlbert(50, 2, seed) },
+		"WattsStrogatz":  func(seed int64) (Graph, error) { return WattsStrogatz(50, 4, 0.3, seed) },
+	}
+	for name, generate := range generators {
+		g, err := generate(7)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		h, _ := generate(7)
+		other, _ := generate(8)
+		if fmt.Sprint(g.edges) != fmt.Sprint(h.edges) {
+			t.Fatalf("%s: expected the same graph from the same seed.", name)
+		}
+		if fmt.Sprint(g.edges) == fmt.Sprint(other.edges) {
+			t.Fatalf("%s: expected different graphs from different seeds.", name)
+		}
+		if !isSimpleGraph(g) || g.Order() != 50 || g.IsDirected() {
+			t.Fatalf("%s: expected a simple undirected graph with 50 vertexes.", name)
+		}
+	}
+
+	g, _ := RandomRegular(50, 3, 1)
+	for v := 0; v < g.Order(); v++ {
+		if degree(g, v) != 3 {
+			t.Fatalf("RandomRegular(): expected degree 3, vertex %d has %d.", v, degree(g, v))
+		}
+	}
+	g, _ = BarabasiAlbert(50, 2, 1)
+	if _, count := ConnectedComponents(g); count != 1 || g.Size() != 3+47*2 {
+		t.Fatalf("BarabasiAlbert(): expected a connected graph with 97 edges, got %d components and %d edges.", count, g.Size())
+	}
+	g, _ = WattsStrogatz(50, 4, 0, 1)
+	for v := 0; v < g.Order(); v++ {
+		if degree(g, v) != 4 {
+			t.Fatalf("WattsStrogatz(): expected a ring lattice of degree 4, vertex %d has %d.", v, degree(g, v))
+		}
+	}
+	if g, _ = WattsStrogatz(50, 4, 1, 1); g.Size() != 100 {
+		t.Fatalf("WattsStrogatz(): expected rewiring to keep 100 edges, got %d.", g.Size())
+	}
+	if g, _ = ErdosRenyi(20, 1, 1); g.Size() != 190 {
+		t.Fatalf("ErdosRenyi(20, 1): expected the complete graph, got %d edges.", g.Size())
+	}
+	if g, _ = RandomRegular(4, 3, 1); g.Size() != 6 {
+		t.Fatalf("RandomRegular(4, 3): expected K(4), got %d edges.", g.Size())
+	}
+}
+
+// TestGeneratorErrors:
+// Verify invalid parameters are rejected
+func TestGeneratorErrors(t *testing.T) {
+	if _, err := ErdosRenyi(10, 1.5, 1); err == nil {
+		t.Fatalf("ErdosRenyi(): expected an error for probability 1.5.")
+	}
+	if _, err := RandomRegular(5, 3, 1); err == nil {
+		t.Fatalf("RandomRegular(5, 3): expected an error for an odd number of endpoints.")
+	}
+	if _, err := RandomRegular(4, 4, 1); err == nil {
+		t.Fatalf("RandomRegular(4, 4): expected an error for a degree not smaller than the order.")
+	}
+	if _, err := BarabasiAlbert(3, 3, 1); err == nil {
+		t.Fatalf("BarabasiAlbert(3, 3): expected an error for m >= n.")
+	}
+	if _, err := WattsStrogatz(10, 3, 0.5, 1); err == nil {
+		t.Fatalf("WattsStrogatz(10, 3): expected an error for an odd k.")
+	}
+}
@@ -1,3 +1,7 @@
 module github.com/extradiable/golang/graphs
 
 go 1.20
+
+replace github.com/extradiable/golang/ds => ../ds
+
+require github.com/extradiable/golang/ds v0.0.0-00010101000000-000000000000
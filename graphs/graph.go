@@ -16,71 +16,189 @@ func (err ErrOutOfBounds) Error() string {
 	return fmt.Sprintf("%s index: %d is out of bounds", err.Type, err.Index)
 }
 
+type ErrRemoved struct {
+	Type  string
+	Index int
+}
+
+func (err ErrRemoved) Error() string {
+	return fmt.Sprintf("%s index: %d was removed", err.Type, err.Index)
+}
+
 // a graph consists of a set of vertices V and a set of edges E
-// instances of this type are created as an empty graph
-// the graph can only grow by using the methods: addVertex and addEdge
+// instances of this type are created as an empty undirected graph
+// use NewDigraph to create an empty directed graph
+// the graph grows by using the methods: AddVertex and AddEdge
+// and shrinks by using the methods: RemoveVertex and RemoveEdge
+// as for slices, the copies of a Graph value share its vertexes and edges:
+// vertexes and edges removed through a copy can be removed from the others too,
+// use a *Graph to share a graph and ToGraph to make an independent copy
 type Graph struct {
-	vertexes []vertex
-	edges    []edge
+	vertexes []Vertex
+	edges    []Edge
+	directed bool
+	// incremented by the changes that can make a path of this graph invalid:
+	// removals and Compact, see Path.Validate
+	version int
+	// incremented by Compact, which renumbers vertexes and edges
+	compactions int
+	// shared by the copies of this graph, so paths of different graphs are told apart
+	identity *identity
+}
+
+// the identity of a graph and its copies
+// it is not empty, so that every identity has its own address
+type identity struct {
+	_ byte
+}
+
+// records a change that can make the paths of this graph invalid
+func (g *Graph) changed() {
+	g.version++
+}
+
+// returns the version and the number of compactions of this graph, see Path.Validate
+func (g Graph) changes() (int, int) {
+	return g.version, g.compactions
+}
+
+// returns the identity of this graph, or nil if it has none yet
+func (g Graph) graphIdentity() *identity {
+	return g.identity
+}
+
+// returns an empty undirected graph
+// this is equivalent to Graph{}
+func NewGraph() Graph {
+	return Graph{identity: &identity{}}
+}
+
+// returns an empty directed graph
+// edges of a directed graph go from their first endpoint to their second endpoint
+func NewDigraph() Graph {
+	return Graph{directed: true, identity: &identity{}}
+}
+
+// returns true if the edges of this graph are directed
+func (g Graph) IsDirected() bool {
+	return g.directed
 }
 
-// returns the number of vertices (|V|) defined in this graph
+// returns the number of vertex ids allocated in this graph
+// vertex ids range over [0, Order()). removed vertexes keep their id until Compact is called,
+// so Order equals |V| only if no vertex was removed, see VertexCount
 func (g Graph) Order() int {
 	return len(g.vertexes)
 }
 
-// returns the number of edges (|E|) defined in this graph
+// returns the number of edge ids allocated in this graph
+// edge ids range over [0, Size()). removed edges keep their id until Compact is called,
+// so Size equals |E| only if no edge was removed, see EdgeCount
 func (g Graph) Size() int {
 	return len(g.edges)
 }
 
+// returns the number of vertices (|V|) in this graph, removed vertexes excluded
+// removed vertexes are counted from their tombstones, which the copies of this graph
+// can share, so this takes O(Order()) time
+func (g Graph) VertexCount() int {
+	count := len(g.vertexes)
+	for i := range g.vertexes {
+		if g.vertexes[i].removed {
+			count--
+		}
+	}
+	return count
+}
+
+// returns the number of edges (|E|) in this graph, removed edges excluded
+// this takes O(Size()) time, see VertexCount
+func (g Graph) EdgeCount() int {
+	count := len(g.edges)
+	for i := range g.edges {
+		if g.edges[i].removed {
+			count--
+		}
+	}
+	return count
+}
+
 // appends a vertex to this graph
 // returns the id associated with this vertex. vertexes are 0-based indexed.
 func (g *Graph) AddVertex(data interface{}) int {
 	id := int(len(g.vertexes))
-	v := vertex{
-		id:   id,
-		out:  make([]out, 0),
-		Data: data,
+	v := Vertex{
+		id:       id,
+		out:      make([]Adjacency, 0),
+		directed: g.directed,
+		Data:     data,
 	}
 	g.vertexes = append(g.vertexes, v)
+	if g.identity == nil {
+		g.identity = &identity{}
+	}
 	return id
 }
 
-// appends an an undirected edge whose endpoints are V[vi] and V[vj] to this graph
+// appends an edge whose endpoints are V[vi] and V[vj] to this graph
+// if the graph is directed the edge goes from V[vi] to V[vj]
 // returns the id associated with the resulting edge. edges are 0-based indexed.
 // an error is returned if 0 >= vi, vj < |V|
+// the weight of the edge is set to 1, see AddWeightedEdge
 func (g *Graph) AddEdge(vi, vj int, data interface{}) (int, error) {
+	return g.AddWeightedEdge(vi, vj, 1, data)
+}
+
+// same as AddEdge but sets the weight of the resulting edge
+func (g *Graph) AddWeightedEdge(vi, vj int, weight float64, data interface{}) (int, error) {
 	var edgeId int = -1
 	if err := g.testVertex(vi, vj); err != nil {
 		return edgeId, err
 	}
 	edgeId = len(g.edges)
-	edge := edge{
+	edge := Edge{
 		id:        edgeId,
-		endpoints: []int{vi, vj},
+		endpoints: [2]int{vi, vj},
+		weight:    weight,
 		Data:      data,
 	}
 	g.edges = append(g.edges, edge)
-	g.vertexes[vi].out = append(g.vertexes[vi].out, out{vj, edgeId})
-	if vi != vj {
-		g.vertexes[vj].out = append(g.vertexes[vj].out, out{vi, edgeId})
+	g.vertexes[vi].out = append(g.vertexes[vi].out, Adjacency{vj, edgeId})
+	if g.directed {
+		g.vertexes[vj].in = append(g.vertexes[vj].in, Adjacency{vi, edgeId})
+	} else if vi != vj {
+		g.vertexes[vj].out = append(g.vertexes[vj].out, Adjacency{vi, edgeId})
 	}
 	return edgeId, nil
 }
 
 // returns an array of vertexes and edges adjacent to this vertex
+// for directed graphs only the edges leaving this vertex are returned
 // or an error if the vertexIndex is out of bounds
-func (g Graph) GetAdjacencies(vertexIndex int) ([]out, error) {
+func (g Graph) GetAdjacencies(vertexIndex int) ([]Adjacency, error) {
 	if err := g.testVertex(vertexIndex); err != nil {
 		return nil, err
 	}
 	return g.vertexes[vertexIndex].out, nil
 }
 
+// returns an array of vertexes and edges entering this vertex
+// the Endpoint of each entry is the vertex the edge comes from
+// for undirected graphs this is the same as GetAdjacencies
+// or an error if the vertexIndex is out of bounds
+func (g Graph) GetInAdjacencies(vertexIndex int) ([]Adjacency, error) {
+	if err := g.testVertex(vertexIndex); err != nil {
+		return nil, err
+	}
+	if !g.directed {
+		return g.vertexes[vertexIndex].out, nil
+	}
+	return g.vertexes[vertexIndex].in, nil
+}
+
 // returns a pointer to the the vertex V[vertexIndex]
 // or error if the vertexIndex is out of bounds
-func (g Graph) GetVertex(vertexIndex int) (*vertex, error) {
+func (g Graph) GetVertex(vertexIndex int) (*Vertex, error) {
 	if err := g.testVertex(vertexIndex); err != nil {
 		return nil, err
 	}
@@ -89,46 +207,52 @@ func (g Graph) GetVertex(vertexIndex int) (*vertex, error) {
 
 // returns a pointer to the edge E[edgeIndex]
 // or error if the edgeIndex is out of bounds
-func (g Graph) GetEdge(edgeIndex int) (*edge, error) {
+func (g Graph) GetEdge(edgeIndex int) (*Edge, error) {
 	if err := g.testEdge(edgeIndex); err != nil {
 		return nil, err
 	}
 	return &g.edges[edgeIndex], nil
 }
 
-// returns true if 0 >= index < len(V)
+// returns true if 0 >= index < Order() and the vertex was not removed
 // returns false otherwise
-func (g Graph) vertexExists(index int) bool {
+func (g Graph) VertexExists(index int) bool {
 	if index < 0 || index >= len(g.vertexes) {
 		return false
 	}
-	return true
+	return !g.vertexes[index].removed
 }
 
-// returns an error if any of the vertex-indexes is out of bounds
+// returns an error if any of the vertex-indexes is out of bounds or was removed
 func (g Graph) testVertex(indexList ...int) error {
 	for i := 0; i < len(indexList); i++ {
-		if b := g.vertexExists(indexList[i]); !b {
-			return ErrOutOfBounds{"vertex", indexList[i]}
+		if b := g.VertexExists(indexList[i]); !b {
+			if indexList[i] < 0 || indexList[i] >= len(g.vertexes) {
+				return ErrOutOfBounds{"vertex", indexList[i]}
+			}
+			return ErrRemoved{"vertex", indexList[i]}
 		}
 	}
 	return nil
 }
 
-// returns true if 0 >= index < |E|
+// returns true if 0 >= index < Size() and the edge was not removed
 // returns false otherwise
 func (g Graph) EdgeExists(index int) bool {
 	if index < 0 || index >= len(g.edges) {
 		return false
 	}
-	return true
+	return !g.edges[index].removed
 }
 
-// returns an error if any of the edge-indexes is out of bounds
+// returns an error if any of the edge-indexes is out of bounds or was removed
 func (g Graph) testEdge(indexList ...int) error {
 	for i := 0; i < len(indexList); i++ {
 		if b := g.EdgeExists(indexList[i]); !b {
-			return ErrOutOfBounds{"edge", indexList[i]}
+			if indexList[i] < 0 || indexList[i] >= len(g.edges) {
+				return ErrOutOfBounds{"edge", indexList[i]}
+			}
+			return ErrRemoved{"edge", indexList[i]}
 		}
 	}
 	return nil
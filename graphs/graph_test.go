@@ -5,7 +5,7 @@ import (
 	"testing"
 )
 
-func printPathLabels(vi, vj *vertex, e *edge) {
+func printPathLabels(vi, vj *Vertex, e *Edge) {
 	if e == nil {
 		fmt.Printf("%v\n", vi.Data)
 	} else {
@@ -34,3 +34,85 @@ func TestEmptyStack(t *testing.T) {
 		t.Fatalf(err.Error())
 	}
 }
+
+// TestVertexAndEdgeAccessors:
+// Verify the exported views of vertexes and edges
+func TestVertexAndEdgeAccessors(t *testing.T) {
+	g := Graph{}
+	for i := 0; i < 3; i++ {
+		g.AddVertex(fmt.Sprintf("V%d", i+1))
+	}
+	g.AddEdge(0, 1, "E1")
+	g.AddEdge(1, 2, "E2")
+	g.AddEdge(1, 1, "E3")
+	v, err := g.GetVertex(1)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if v.ID() != 1 || v.Data != "V2" {
+		t.Fatalf("GetVertex(1): expected vertex 1 with data V2, got %d with data %v.", v.ID(), v.Data)
+	}
+	if v.Degree() != 4 {
+		t.Fatalf("Degree(): expected value 4, got %d.", v.Degree())
+	}
+	neighbors := v.Neighbors()
+	if len(neighbors) != 3 || neighbors[0] != 0 || neighbors[1] != 2 || neighbors[2] != 1 {
+		t.Fatalf("Neighbors(): expected [0 2 1], got %v.", neighbors)
+	}
+	e, err := g.GetEdge(1)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if vi, vj := e.Endpoints(); vi != 1 || vj != 2 || e.Other(2) != 1 {
+		t.Fatalf("Endpoints(): expected (1, 2), got (%d, %d).", vi, vj)
+	}
+}
+
+// TestDigraph:
+// Verify edges of directed graphs are followed in their direction only
+func TestDigraph(t *testing.T) {
+	g := NewDigraph()
+	for i := 0; i < 4; i++ {
+		g.AddVertex(fmt.Sprintf("V%d", i+1))
+	}
+	g.AddEdge(0, 1, "E1")
+	g.AddEdge(1, 2, "E2")
+	g.AddEdge(3, 1, "E3")
+	g.AddEdge(2, 0, "E4")
+	v, _ := g.GetVertex(1)
+	if v.InDegree() != 2 || v.OutDegree() != 1 || v.Degree() != 3 {
+		t.Fatalf("vertex 1: expected in/out degree 2/1, got %d/%d.", v.InDegree(), v.OutDegree())
+	}
+	path, err := Trace(g, 0)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if len(path.out) != 3 || path.GetLastVertex() != 0 {
+		t.Fatalf("Trace(g, 0): expected the cycle 0 1 2 0, got %v.", path.out)
+	}
+	path, _ = g.NewPath(1)
+	if _, err := path.Grow(0, 0); err == nil {
+		t.Fatalf("path.Grow(0, 0): expected an error growing against the edge direction")
+	}
+}
+
+// TestPathWeight:
+// Verify the weight of a path is the sum of the weights of its edges
+func TestPathWeight(t *testing.T) {
+	g := Graph{}
+	for i := 0; i < 3; i++ {
+		g.AddVertex(i)
+	}
+	g.AddWeightedEdge(0, 1, 2.5, nil)
+	g.AddEdge(1, 2, nil)
+	path, _ := g.NewPath(0)
+	path, _ = path.Grow(1, 0)
+	path, _ = path.Grow(2, 1)
+	if w, err := path.Weight(); err != nil || w != 3.5 {
+		t.Fatalf("path.Weight(): expected value 3.5, got %v (%v).", w, err)
+	}
+	double := func(e *Edge) float64 { return 2 * e.Weight() }
+	if w, _ := path.WeightBy(double); w != 7 {
+		t.Fatalf("path.WeightBy(double): expected value 7, got %v.", w)
+	}
+}
@@ -0,0 +1,202 @@
+package graph
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const graphMLNamespace = "http://graphml.graphdrawing.org/xmlns"
+
+type graphMLDocument struct {
+	XMLName xml.Name     `xml:"graphml"`
+	Xmlns   string       `xml:"xmlns,attr,omitempty"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID   string `xml:"id,attr"`
+	For  string `xml:"for,attr"`
+	Name string `xml:"attr.name,attr"`
+	Type string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr,omitempty"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	ID     string        `xml:"id,attr,omitempty"`
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// writes g to w as a GraphML document
+// vertexes are written as nodes with ids "n<id>" and edges as edges with ids "e<id>".
+// Data is encoded with the given codecs and stored under the keys "vdata" and "edata",
+// edge weights are stored under the key "weight". removed vertexes and edges are skipped
+func WriteGraphML(w io.Writer, g Interface, codecs Codecs) error {
+	edgeDefault := "undirected"
+	if g.IsDirected() {
+		edgeDefault = "directed"
+	}
+	doc := graphMLDocument{
+		Xmlns: graphMLNamespace,
+		Keys: []graphMLKey{
+			{"vdata", "node", "data", "string"},
+			{"edata", "edge", "data", "string"},
+			{"weight", "edge", "weight", "double"},
+		},
+		Graph: graphMLGraph{ID: "G", EdgeDefault: edgeDefault},
+	}
+	for i := 0; i < g.Order(); i++ {
+		data, err := g.VertexData(i)
+		if err != nil {
+			// removed vertex
+			continue
+		}
+		node := graphMLNode{ID: fmt.Sprintf("n%d", i)}
+		if data != nil {
+			text, err := codecs.vertex().Encode(data)
+			if err != nil {
+				return fmt.Errorf("vertex: %d: %v", i, err)
+			}
+			node.Data = append(node.Data, graphMLData{"vdata", text})
+		}
+		doc.Graph.Nodes = append(doc.Graph.Nodes, node)
+	}
+	for i := 0; i < g.Size(); i++ {
+		vi, vj, err := g.Endpoints(i)
+		if err != nil {
+			// removed edge
+			continue
+		}
+		weight, _ := g.Weight(i)
+		edge := graphMLEdge{
+			ID:     fmt.Sprintf("e%d", i),
+			Source: fmt.Sprintf("n%d", vi),
+			Target: fmt.Sprintf("n%d", vj),
+			Data:   []graphMLData{{"weight", strconv.FormatFloat(weight, 'g', -1, 64)}},
+		}
+		if data, _ := g.EdgeData(i); data != nil {
+			text, err := codecs.edge().Encode(data)
+			if err != nil {
+				return fmt.Errorf("edge: %d: %v", i, err)
+			}
+			edge.Data = append(edge.Data, graphMLData{"edata", text})
+		}
+		doc.Graph.Edges = append(doc.Graph.Edges, edge)
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// reads a GraphML document
+// nodes and edges with ids of the form "n<id>" and "e<id>", as written by WriteGraphML,
+// keep their ids. otherwise ids are assigned in order of appearance.
+// Data is decoded with the given codecs from the data elements whose key is named "data",
+// edge weights from the data elements whose key is named "weight"
+func ReadGraphML(r io.Reader, codecs Codecs) (Graph, error) {
+	var doc graphMLDocument
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return Graph{}, err
+	}
+	// maps the key ids of the document to the attribute they hold
+	keys := map[string]string{"vdata": "data", "edata": "data", "weight": "weight"}
+	for _, key := range doc.Keys {
+		keys[key.ID] = key.Name
+	}
+	b := newGraphBuilder(doc.Graph.EdgeDefault == "directed")
+	nodes := graphMLIds(len(doc.Graph.Nodes), "n", func(i int) string { return doc.Graph.Nodes[i].ID })
+	for _, node := range doc.Graph.Nodes {
+		var data interface{}
+		for _, d := range node.Data {
+			if keys[d.Key] == "data" {
+				var err error
+				if data, err = codecs.vertex().Decode(d.Value); err != nil {
+					return Graph{}, fmt.Errorf("node: %s: %v", node.ID, err)
+				}
+			}
+		}
+		if err := b.vertex(nodes[node.ID], data); err != nil {
+			return Graph{}, err
+		}
+	}
+	edges := graphMLIds(len(doc.Graph.Edges), "e", func(i int) string { return doc.Graph.Edges[i].ID })
+	for i, edge := range doc.Graph.Edges {
+		var data interface{}
+		weight := 1.0
+		for _, d := range edge.Data {
+			var err error
+			switch keys[d.Key] {
+			case "data":
+				data, err = codecs.edge().Decode(d.Value)
+			case "weight":
+				weight, err = strconv.ParseFloat(strings.TrimSpace(d.Value), 64)
+			}
+			if err != nil {
+				return Graph{}, fmt.Errorf("edge: %s: %v", edge.ID, err)
+			}
+		}
+		source, ok := nodes[edge.Source]
+		if !ok {
+			return Graph{}, fmt.Errorf("edge: %s: unknown source node %q", edge.ID, edge.Source)
+		}
+		target, ok := nodes[edge.Target]
+		if !ok {
+			return Graph{}, fmt.Errorf("edge: %s: unknown target node %q", edge.ID, edge.Target)
+		}
+		id := i
+		if edge.ID != "" {
+			id = edges[edge.ID]
+		}
+		if err := b.edge(id, source, target, weight, data); err != nil {
+			return Graph{}, err
+		}
+	}
+	return b.build()
+}
+
+// maps the ids of n GraphML elements to graph ids
+// ids of the form prefix<id> are kept if all elements use that form,
+// otherwise elements are numbered in order of appearance
+func graphMLIds(n int, prefix string, id func(i int) string) map[string]int {
+	ids := make(map[string]int, n)
+	for i := 0; i < n; i++ {
+		number, err := strconv.Atoi(strings.TrimPrefix(id(i), prefix))
+		if err != nil || !strings.HasPrefix(id(i), prefix) || number < 0 {
+			ids = make(map[string]int, n)
+			for j := 0; j < n; j++ {
+				ids[id(j)] = j
+			}
+			return ids
+		}
+		ids[id(i)] = number
+	}
+	return ids
+}
@@ -0,0 +1,277 @@
+package graph
+
+// Interface is the read-only view of a graph accepted by Trace, Path and the
+// algorithms of this package, so they run on any graph representation:
+// Graph (adjacency lists), MatrixGraph (adjacency matrix), CSRGraph (compressed sparse rows)
+// or any other type implementing it
+//
+// vertex ids range over [0, Order()) and edge ids over [0, Size()).
+// the methods taking an id return an error for ids that do not exist, such as removed ones.
+// callbacks that receive a *Vertex or *Edge, such as a WeightFunc, get views built from
+// these methods, unless the graph also has the GetVertex and GetEdge methods of Graph
+type Interface interface {
+	// returns the number of vertex ids
+	Order() int
+	// returns the number of edge ids
+	Size() int
+	// returns true if the edges go from their first endpoint to their second endpoint
+	IsDirected() bool
+	// returns the edges leaving vertexIndex and the vertexes they lead to
+	// for undirected graphs a self-loop is listed once
+	Adjacencies(vertexIndex int) ([]Adjacency, error)
+	// returns the edges entering vertexIndex and the vertexes they come from
+	// for undirected graphs this is the same as Adjacencies
+	InAdjacencies(vertexIndex int) ([]Adjacency, error)
+	// returns the endpoints of edgeIndex
+	Endpoints(edgeIndex int) (int, int, error)
+	// returns the Data of the vertex V[vertexIndex]
+	VertexData(vertexIndex int) (interface{}, error)
+	// returns the Data of the edge E[edgeIndex]
+	EdgeData(edgeIndex int) (interface{}, error)
+	// returns the weight of the edge E[edgeIndex]
+	Weight(edgeIndex int) (float64, error)
+}
+
+// implemented by graphs that store Vertex and Edge values, see Interface
+type elementGetter interface {
+	GetVertex(vertexIndex int) (*Vertex, error)
+	GetEdge(edgeIndex int) (*Edge, error)
+}
+
+// an entry of an adjacency list: an edge and the vertex at its other end
+type Adjacency struct {
+	Endpoint int
+	Edge     int
+}
+
+// returns an array of vertexes and edges adjacent to this vertex
+// for directed graphs only the edges leaving this vertex are returned
+// or an error if the vertexIndex is out of bounds
+func (g Graph) Adjacencies(vertexIndex int) ([]Adjacency, error) {
+	return g.GetAdjacencies(vertexIndex)
+}
+
+// returns an array of vertexes and edges entering this vertex
+// see GetInAdjacencies
+func (g Graph) InAdjacencies(vertexIndex int) ([]Adjacency, error) {
+	return g.GetInAdjacencies(vertexIndex)
+}
+
+// returns the ids of the vertexes joined by the edge E[edgeIndex]
+// or an error if the edgeIndex is out of bounds
+func (g Graph) Endpoints(edgeIndex int) (int, int, error) {
+	if err := g.testEdge(edgeIndex); err != nil {
+		return -1, -1, err
+	}
+	vi, vj := g.edges[edgeIndex].Endpoints()
+	return vi, vj, nil
+}
+
+// returns the Data of the vertex V[vertexIndex]
+// or an error if the vertexIndex is out of bounds
+func (g Graph) VertexData(vertexIndex int) (interface{}, error) {
+	if err := g.testVertex(vertexIndex); err != nil {
+		return nil, err
+	}
+	return g.vertexes[vertexIndex].Data, nil
+}
+
+// returns the Data of the edge E[edgeIndex]
+// or an error if the edgeIndex is out of bounds
+func (g Graph) EdgeData(edgeIndex int) (interface{}, error) {
+	if err := g.testEdge(edgeIndex); err != nil {
+		return nil, err
+	}
+	return g.edges[edgeIndex].Data, nil
+}
+
+// returns the weight of the edge E[edgeIndex]
+// or an error if the edgeIndex is out of bounds
+func (g Graph) Weight(edgeIndex int) (float64, error) {
+	if err := g.testEdge(edgeIndex); err != nil {
+		return 0, err
+	}
+	return g.edges[edgeIndex].weight, nil
+}
+
+// returns the adjacencies of a vertex known to exist
+func adjacencies(g Interface, vertexIndex int) []Adjacency {
+	a, _ := g.Adjacencies(vertexIndex)
+	return a
+}
+
+// returns the in-adjacencies of a vertex known to exist
+func inAdjacencies(g Interface, vertexIndex int) []Adjacency {
+	a, _ := g.InAdjacencies(vertexIndex)
+	return a
+}
+
+// returns the vertex V[vertexIndex] of g
+// graphs without a GetVertex method get a view built from their Interface methods
+func getVertex(g Interface, vertexIndex int) (*Vertex, error) {
+	if getter, ok := g.(elementGetter); ok {
+		return getter.GetVertex(vertexIndex)
+	}
+	out, err := g.Adjacencies(vertexIndex)
+	if err != nil {
+		return nil, err
+	}
+	v := &Vertex{id: vertexIndex, out: out, directed: g.IsDirected()}
+	if v.directed {
+		v.in = inAdjacencies(g, vertexIndex)
+	}
+	v.Data, err = g.VertexData(vertexIndex)
+	return v, err
+}
+
+// returns the edge E[edgeIndex] of g
+// graphs without a GetEdge method get a view built from their Interface methods
+func getEdge(g Interface, edgeIndex int) (*Edge, error) {
+	if getter, ok := g.(elementGetter); ok {
+		return getter.GetEdge(edgeIndex)
+	}
+	vi, vj, err := g.Endpoints(edgeIndex)
+	if err != nil {
+		return nil, err
+	}
+	e := &Edge{id: edgeIndex, endpoints: [2]int{vi, vj}}
+	if e.weight, err = g.Weight(edgeIndex); err != nil {
+		return nil, err
+	}
+	e.Data, err = g.EdgeData(edgeIndex)
+	return e, err
+}
+
+// returns the vertex V[vertexIndex], known to exist
+func vertexAt(g Interface, vertexIndex int) *Vertex {
+	v, _ := getVertex(g, vertexIndex)
+	return v
+}
+
+// returns the edge E[edgeIndex], or nil if it does not exist
+func edgeAt(g Interface, edgeIndex int) *Edge {
+	e, _ := getEdge(g, edgeIndex)
+	return e
+}
+
+// returns true if V[vertexIndex] exists in g
+func vertexExists(g Interface, vertexIndex int) bool {
+	_, err := g.Adjacencies(vertexIndex)
+	return err == nil
+}
+
+// returns true if E[edgeIndex] exists in g
+func edgeExists(g Interface, edgeIndex int) bool {
+	_, _, err := g.Endpoints(edgeIndex)
+	return err == nil
+}
+
+// returns an error if any of the vertex-indexes does not exist in g
+func testVertex(g Interface, indexList ...int) error {
+	for _, index := range indexList {
+		if _, err := g.Adjacencies(index); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// returns the number of vertexes of g, excluding ids that do not exist
+func vertexCount(g Interface) int {
+	count := 0
+	for v := 0; v < g.Order(); v++ {
+		if vertexExists(g, v) {
+			count++
+		}
+	}
+	return count
+}
+
+// returns a function giving the cost of the edges of g, known to exist, as given by w
+// the edge weights are read directly if w is nil. otherwise w gets the view of every edge
+// once and its result is kept, so graphs that build a view on every GetEdge call,
+// such as CSRGraph, do not build one per lookup
+func edgeCosts(g Interface, w WeightFunc) func(edgeIndex int) float64 {
+	if w == nil {
+		return func(edgeIndex int) float64 {
+			weight, _ := g.Weight(edgeIndex)
+			return weight
+		}
+	}
+	switch g.(type) {
+	case Graph, *Graph, MatrixGraph:
+		// their views point to the edges they store, getting them does not allocate
+		return func(edgeIndex int) float64 {
+			return w(edgeAt(g, edgeIndex))
+		}
+	}
+	costs := make([]float64, g.Size())
+	known := make([]bool, g.Size())
+	return func(edgeIndex int) float64 {
+		if !known[edgeIndex] {
+			costs[edgeIndex], known[edgeIndex] = w(edgeAt(g, edgeIndex)), true
+		}
+		return costs[edgeIndex]
+	}
+}
+
+// returns the number of edges incident to a vertex, 0 if it does not exist
+// self-loops are counted twice, as Vertex.Degree does
+func degree(g Interface, vertexIndex int) int {
+	out, err := g.Adjacencies(vertexIndex)
+	if err != nil {
+		return 0
+	}
+	if g.IsDirected() {
+		return len(out) + len(inAdjacencies(g, vertexIndex))
+	}
+	degree := len(out)
+	for _, o := range out {
+		if o.Endpoint == vertexIndex {
+			degree++
+		}
+	}
+	return degree
+}
+
+// returns a graph with the vertexes and edges of g, keeping their ids and payloads,
+// but without adjacency lists. ids that do not exist in g are kept as removed
+func skeleton(g Interface) Graph {
+	s := Graph{
+		vertexes: make([]Vertex, g.Order()),
+		edges:    make([]Edge, g.Size()),
+		directed: g.IsDirected(),
+		identity: &identity{},
+	}
+	for i := range s.vertexes {
+		s.vertexes[i] = Vertex{id: i, directed: s.directed, removed: true}
+		if data, err := g.VertexData(i); err == nil {
+			s.vertexes[i].removed = false
+			s.vertexes[i].Data = data
+		}
+	}
+	for i := range s.edges {
+		s.edges[i] = Edge{id: i, endpoints: [2]int{-1, -1}, removed: true}
+		if e, err := getEdge(g, i); err == nil {
+			s.edges[i] = Edge{id: i, endpoints: e.endpoints, weight: e.weight, Data: e.Data}
+		}
+	}
+	return s
+}
+
+// returns an adjacency list copy of g that can be modified
+// the ids, payloads and adjacency order of g are kept
+func ToGraph(g Interface) Graph {
+	s := skeleton(g)
+	for i := range s.vertexes {
+		v := &s.vertexes[i]
+		if v.removed {
+			continue
+		}
+		v.out = append(make([]Adjacency, 0), adjacencies(g, i)...)
+		if s.directed {
+			v.in = append([]Adjacency(nil), inAdjacencies(g, i)...)
+		}
+	}
+	return s
+}
@@ -0,0 +1,290 @@
+package graph
+
+import (
+	"errors"
+	"testing"
+)
+
+// returns the same graph in the three representations of this package
+func representations(g Graph) map[string]Interface {
+	return map[string]Interface{
+		"list":   g,
+		"matrix": NewMatrixGraph(g),
+		"csr":    NewCSRGraph(g),
+	}
+}
+
+// TestRepresentationQueries:
+// Verify the three representations answer the same queries
+func TestRepresentationQueries(t *testing.T) {
+	g := NewDigraph()
+	for i := 0; i < 6; i++ {
+		g.AddVertex(i)
+	}
+	g.AddWeightedEdge(0, 1, 4, nil)
+	g.AddWeightedEdge(0, 2, 1, nil)
+	g.AddWeightedEdge(2, 1, 2, nil)
+	g.AddWeightedEdge(1, 3, 5, nil)
+	g.AddWeightedEdge(2, 3, 8, nil)
+	g.AddWeightedEdge(3, 4, 3, nil)
+	g.AddWeightedEdge(2, 4, 1, nil)
+	g.AddWeightedEdge(4, 5, 1, nil)
+	g.RemoveEdge(6)
+	g.RemoveVertex(5)
+	for name, r := range representations(g) {
+		if r.Order() != 6 || r.Size() != 8 || !r.IsDirected() {
+			t.Fatalf("%s: expected 6 vertex ids and 8 edge ids, got %d and %d.", name, r.Order(), r.Size())
+		}
+		var removed ErrRemoved
+		if _, err := r.VertexData(5); !errors.As(err, &removed) {
+			t.Fatalf("%s: expected ErrRemoved for vertex 5, got %v.", name, err)
+		}
+		if _, err := r.Weight(6); !errors.As(err, &removed) {
+			t.Fatalf("%s: expected ErrRemoved for edge 6, got %v.", name, err)
+		}
+		var outOfBounds ErrOutOfBounds
+		if _, err := r.Adjacencies(6); !errors.As(err, &outOfBounds) {
+			t.Fatalf("%s: expected ErrOutOfBounds for vertex 6, got %v.", name, err)
+		}
+		if vi, vj, err := r.Endpoints(3); err != nil || vi != 1 || vj != 3 {
+			t.Fatalf("%s: expected edge 3 to go from 1 to 3, got %d %d %v.", name, vi, vj, err)
+		}
+		for v := 0; v < 5; v++ {
+			out, _ := r.Adjacencies(v)
+			in, _ := r.InAdjacencies(v)
+			vertex, _ := getVertex(r, v)
+			if len(out) != vertex.OutDegree() || len(in) != vertex.InDegree() || vertex.Data != v {
+				t.Fatalf("%s: unexpected vertex %d: %v %v %v.", name, v, out, in, vertex)
+			}
+			gv, _ := g.GetVertex(v)
+			if vertex.Degree() != gv.Degree() {
+				t.Fatalf("%s: expected vertex %d to have degree %d, got %d.", name, v, gv.Degree(), vertex.Degree())
+			}
+		}
+	}
+}
+
+// TestRepresentationAlgorithms:
+// Verify the algorithms give the same results on the three representations
+func TestRepresentationAlgorithms(t *testing.T) {
+	g := NewDigraph()
+	for i := 0; i < 6; i++ {
+		g.AddVertex(i)
+	}
+	g.AddWeightedEdge(0, 1, 4, nil)
+	g.AddWeightedEdge(0, 2, 1, nil)
+	g.AddWeightedEdge(2, 1, 2, nil)
+	g.AddWeightedEdge(1, 3, 5, nil)
+	g.AddWeightedEdge(2, 3, 8, nil)
+	g.AddWeightedEdge(3, 4, 3, nil)
+	g.AddWeightedEdge(2, 4, 1, nil)
+	g.AddWeightedEdge(4, 5, 1, nil)
+	g.RemoveEdge(6)
+	g.RemoveVertex(5)
+	expected := []float64{0, 3, 1, 8, 11}
+	for name, r := range representations(g) {
+		sp, err := Dijkstra(r, 0, nil)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		for v, d := range expected {
+			if sp.Distance(v) != d {
+				t.Fatalf("%s: Dijkstra(): expected distance %v to %d, got %v.", name, d, v, sp.Distance(v))
+			}
+		}
+		p, err := sp.PathTo(4)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if w, _ := p.Weight(); w != 11 {
+			t.Fatalf("%s: PathTo(): expected weight 11, got %v.", name, w)
+		}
+		order, err := TopologicalSort(r)
+		if err != nil || len(order) != 5 {
+			t.Fatalf("%s: TopologicalSort(): unexpected order %v %v.", name, order, err)
+		}
+		f, err := Dinic(r, 0, 4, nil)
+		if err != nil || f.Value != 3 {
+			t.Fatalf("%s: Dinic(): expected flow 3, got %v %v.", name, f.Value, err)
+		}
+		_, count := StronglyConnectedComponents(r)
+		if count != 5 {
+			t.Fatalf("%s: StronglyConnectedComponents(): expected 5 components, got %d.", name, count)
+		}
+	}
+}
+
+// TestRepresentationUndirected:
+// Verify undirected graphs, self-loops and parallel edges on the three representations
+func TestRepresentationUndirected(t *testing.T) {
+	g := Graph{}
+	for i := 0; i < 4; i++ {
+		g.AddVertex(nil)
+	}
+	g.AddEdge(0, 1, nil)
+	g.AddEdge(1, 2, nil)
+	g.AddEdge(2, 0, nil)
+	g.AddEdge(2, 2, nil)
+	g.AddEdge(2, 3, nil)
+	g.AddEdge(3, 2, nil)
+	for name, r := range representations(g) {
+		if d := degree(r, 2); d != 6 {
+			t.Fatalf("%s: expected vertex 2 to have degree 6, got %d.", name, d)
+		}
+		p, err := EulerCircuit(r)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if p.GetLastVertex() != 0 || len(p.out) != 6 {
+			t.Fatalf("%s: EulerCircuit(): expected a closed walk over 6 edges, got %v.", name, p.out)
+		}
+		forest, err := Kruskal(r, nil)
+		if err != nil || len(forest.Edges) != 3 {
+			t.Fatalf("%s: Kruskal(): expected 3 edges, got %v %v.", name, forest.Edges, err)
+		}
+		if _, err := Trace(r, 0); err != nil {
+			t.Fatalf("%s: Trace(): %v", name, err)
+		}
+	}
+	m := NewMatrixGraph(g)
+	if edges, _ := m.EdgesBetween(3, 2); len(edges) != 2 {
+		t.Fatalf("EdgesBetween(): expected 2 parallel edges, got %v.", edges)
+	}
+	if edges, _ := m.EdgesBetween(2, 2); len(edges) != 1 {
+		t.Fatalf("EdgesBetween(): expected 1 self-loop, got %v.", edges)
+	}
+}
+
+// a directed cycle implementing Interface without GetVertex and GetEdge:
+// edge i goes from vertex i to vertex i+1 and weighs i+1
+type ring int
+
+func (r ring) Order() int       { return int(r) }
+func (r ring) Size() int        { return int(r) }
+func (r ring) IsDirected() bool { return true }
+
+func (r ring) Adjacencies(v int) ([]Adjacency, error) {
+	if v < 0 || v >= int(r) {
+		return nil, ErrOutOfBounds{"vertex", v}
+	}
+	return []Adjacency{{(v + 1) % int(r), v}}, nil
+}
+
+func (r ring) InAdjacencies(v int) ([]Adjacency, error) {
+	if v < 0 || v >= int(r) {
+		return nil, ErrOutOfBounds{"vertex", v}
+	}
+	u := (v + int(r) - 1) % int(r)
+	return []Adjacency{{u, u}}, nil
+}
+
+func (r ring) Endpoints(e int) (int, int, error) {
+	if e < 0 || e >= int(r) {
+		return -1, -1, ErrOutOfBounds{"edge", e}
+	}
+	return e, (e + 1) % int(r), nil
+}
+
+func (r ring) VertexData(v int) (interface{}, error) {
+	_, err := r.Adjacencies(v)
+	return v, err
+}
+
+func (r ring) EdgeData(e int) (interface{}, error) {
+	_, _, err := r.Endpoints(e)
+	return nil, err
+}
+
+func (r ring) Weight(e int) (float64, error) {
+	_, _, err := r.Endpoints(e)
+	return float64(e + 1), err
+}
+
+// TestCustomInterface:
+// Verify the algorithms run on a type implementing only Interface
+func TestCustomInterface(t *testing.T) {
+	r := ring(5)
+	sp, err := Dijkstra(r, 0, nil)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if sp.Distance(3) != 6 {
+		t.Fatalf("Dijkstra(): expected distance 6 to vertex 3, got %v.", sp.Distance(3))
+	}
+	p, err := sp.PathTo(3)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	data, weight := make([]interface{}, 0), 0.0
+	err = p.TraversePath(func(vi, vj *Vertex, e *Edge) {
+		data = append(data, vj.Data)
+		weight += e.Weight()
+	})
+	if err != nil || len(data) != 3 || data[2] != 3 || weight != 6 {
+		t.Fatalf("TraversePath(): expected to reach vertex 3 over weight 6, got %v %v %v.", data, weight, err)
+	}
+	if degree(r, 2) != 2 {
+		t.Fatalf("expected vertex 2 to have degree 2, got %d.", degree(r, 2))
+	}
+	circuit, err := EulerCircuit(r)
+	if err != nil || len(circuit.out) != 5 {
+		t.Fatalf("EulerCircuit(): expected a closed walk over 5 edges, got %v %v.", circuit.out, err)
+	}
+	if g := ToGraph(r); g.EdgeCount() != 5 || !g.IsDirected() {
+		t.Fatalf("ToGraph(): expected a digraph with 5 edges, got %d.", g.EdgeCount())
+	}
+}
+
+// TestMatrixAdjacencies:
+// Verify the adjacencies of a matrix are listed once when it is built
+func TestMatrixAdjacencies(t *testing.T) {
+	g := NewDigraph()
+	for i := 0; i < 4; i++ {
+		g.AddVertex(nil)
+	}
+	g.AddEdge(0, 3, nil)
+	g.AddEdge(0, 1, nil)
+	g.AddEdge(2, 1, nil)
+	g.AddEdge(0, 1, nil)
+	m := NewMatrixGraph(g)
+	out, _ := m.Adjacencies(0)
+	if len(out) != 3 || out[0] != (Adjacency{1, 1}) || out[1] != (Adjacency{1, 3}) || out[2] != (Adjacency{3, 0}) {
+		t.Fatalf("Adjacencies(): expected the row of vertex 0 ordered by vertex, got %v.", out)
+	}
+	in, _ := m.InAdjacencies(1)
+	if len(in) != 3 || in[2] != (Adjacency{2, 2}) {
+		t.Fatalf("InAdjacencies(): expected the column of vertex 1 ordered by vertex, got %v.", in)
+	}
+	allocs := testing.AllocsPerRun(10, func() {
+		m.Adjacencies(0)
+		m.InAdjacencies(1)
+		m.GetVertex(2)
+	})
+	if allocs != 0 {
+		t.Fatalf("expected no allocations per query, got %v.", allocs)
+	}
+
+	// parallel edges share a cell and are listed from both ends of undirected edges
+	u := NewGraph()
+	for i := 0; i < 3; i++ {
+		u.AddVertex(nil)
+	}
+	u.AddEdge(1, 0, nil)
+	u.AddEdge(2, 2, nil)
+	u.AddEdge(0, 1, nil)
+	u.RemoveEdge(0)
+	u.AddEdge(1, 0, nil)
+	m = NewMatrixGraph(u)
+	if edges, _ := m.EdgesBetween(0, 1); len(edges) != 2 || edges[0] != 2 || edges[1] != 3 {
+		t.Fatalf("EdgesBetween(): expected edges 2 and 3, got %v.", edges)
+	}
+	if edges, _ := m.EdgesBetween(1, 0); len(edges) != 2 || edges[0] != 2 || edges[1] != 3 {
+		t.Fatalf("EdgesBetween(): expected edges 2 and 3 from the other end, got %v.", edges)
+	}
+	if out, _ := m.Adjacencies(2); len(out) != 1 || out[0] != (Adjacency{2, 1}) {
+		t.Fatalf("Adjacencies(): expected the self-loop once, got %v.", out)
+	}
+	if !m.HasEdge(1, 0) || m.HasEdge(0, 2) || m.HasEdge(0, 3) || len(m.cells) != 9 {
+		t.Fatalf("HasEdge(): unexpected answers over %d cells.", len(m.cells))
+	}
+}
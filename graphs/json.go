@@ -0,0 +1,160 @@
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// the JSON representation of a graph, see WriteJSON
+type jsonGraph struct {
+	Directed bool         `json:"directed"`
+	Vertices []jsonVertex `json:"vertices"`
+	Edges    []jsonEdge   `json:"edges"`
+}
+
+type jsonVertex struct {
+	ID   int             `json:"id"`
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+type jsonEdge struct {
+	ID     int             `json:"id"`
+	Source int             `json:"source"`
+	Target int             `json:"target"`
+	Weight *float64        `json:"weight,omitempty"`
+	Data   json.RawMessage `json:"data,omitempty"`
+}
+
+// writes g to w as a JSON document of the form:
+//
+//	{
+//	  "directed": false,
+//	  "vertices": [{"id": 0, "data": "V1"}, ...],
+//	  "edges": [{"id": 0, "source": 0, "target": 1, "weight": 1, "data": "E1"}, ...]
+//	}
+//
+// "data" holds the text produced by the vertex or edge codec of codecs, as a JSON string,
+// or embedded as is for a JSONCodec. it is omitted for nil Data.
+// removed vertexes and edges are not listed
+func WriteJSON(w io.Writer, g Interface, codecs Codecs) error {
+	doc := jsonGraph{
+		Directed: g.IsDirected(),
+		Vertices: make([]jsonVertex, 0, g.Order()),
+		Edges:    make([]jsonEdge, 0, g.Size()),
+	}
+	for i := 0; i < g.Order(); i++ {
+		data, err := g.VertexData(i)
+		if err != nil {
+			// removed vertex
+			continue
+		}
+		vertex := jsonVertex{ID: i}
+		if data != nil {
+			if vertex.Data, err = encodeJSONPayload(codecs.vertex(), data); err != nil {
+				return fmt.Errorf("vertex: %d: %v", i, err)
+			}
+		}
+		doc.Vertices = append(doc.Vertices, vertex)
+	}
+	for i := 0; i < g.Size(); i++ {
+		vi, vj, err := g.Endpoints(i)
+		if err != nil {
+			// removed edge
+			continue
+		}
+		weight, _ := g.Weight(i)
+		edge := jsonEdge{ID: i, Source: vi, Target: vj, Weight: &weight}
+		if data, _ := g.EdgeData(i); data != nil {
+			if edge.Data, err = encodeJSONPayload(codecs.edge(), data); err != nil {
+				return fmt.Errorf("edge: %d: %v", i, err)
+			}
+		}
+		doc.Edges = append(doc.Edges, edge)
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(doc)
+}
+
+// returns data encoded by codec as a JSON value
+// the text of a JSONCodec is already one, other texts are quoted
+func encodeJSONPayload(codec PayloadCodec, data interface{}) (json.RawMessage, error) {
+	text, err := codec.Encode(data)
+	if err != nil {
+		return nil, err
+	}
+	if isJSONCodec(codec) {
+		return json.RawMessage(text), nil
+	}
+	return json.Marshal(text)
+}
+
+func isJSONCodec(codec PayloadCodec) bool {
+	switch codec.(type) {
+	case JSONCodec, *JSONCodec:
+		return true
+	}
+	return false
+}
+
+// returns the Data held by the JSON value raw, see encodeJSONPayload
+func decodeJSONPayload(codec PayloadCodec, raw json.RawMessage) (interface{}, error) {
+	if isJSONCodec(codec) {
+		return codec.Decode(string(raw))
+	}
+	var text string
+	if err := json.Unmarshal(raw, &text); err != nil {
+		return nil, err
+	}
+	return codec.Decode(text)
+}
+
+// reads a JSON document of the form written by WriteJSON:
+//
+//	{
+//	  "directed": false,
+//	  "vertices": [{"id": 0, "data": "V1"}, ...],
+//	  "edges": [{"id": 0, "source": 0, "target": 1, "weight": 1, "data": "E1"}, ...]
+//	}
+//
+// "data" is decoded by the vertex or edge codec of codecs and is nil when omitted.
+// it must be a JSON string unless the codec is a JSONCodec.
+// "weight" defaults to 1 when omitted. ids missing from the document are restored
+// as removed vertexes and edges
+func ReadJSON(r io.Reader, codecs Codecs) (Graph, error) {
+	var doc jsonGraph
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return Graph{}, err
+	}
+	b := newGraphBuilder(doc.Directed)
+	for _, v := range doc.Vertices {
+		var data interface{}
+		if v.Data != nil {
+			var err error
+			if data, err = decodeJSONPayload(codecs.vertex(), v.Data); err != nil {
+				return Graph{}, fmt.Errorf("vertex: %d: %v", v.ID, err)
+			}
+		}
+		if err := b.vertex(v.ID, data); err != nil {
+			return Graph{}, err
+		}
+	}
+	for _, e := range doc.Edges {
+		var data interface{}
+		if e.Data != nil {
+			var err error
+			if data, err = decodeJSONPayload(codecs.edge(), e.Data); err != nil {
+				return Graph{}, fmt.Errorf("edge: %d: %v", e.ID, err)
+			}
+		}
+		weight := 1.0
+		if e.Weight != nil {
+			weight = *e.Weight
+		}
+		if err := b.edge(e.ID, e.Source, e.Target, weight, data); err != nil {
+			return Graph{}, err
+		}
+	}
+	return b.build()
+}
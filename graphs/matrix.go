@@ -0,0 +1,163 @@
+package graph
+
+// a read-only graph stored as an adjacency matrix
+// the cell at row i and column j holds the edges going from V[i] to V[j],
+// so parallel edges are kept. for undirected graphs every edge is stored
+// in both of its cells, self-loops in a single one
+// the matrix takes 4 bytes per cell, Order()^2 cells, plus 8 bytes per edge
+// chaining the edges that share a cell: use it for small or dense graphs
+// the rows and columns are scanned once when the matrix is built and packed in a single
+// array of adjacencies, so listing the adjacencies of a vertex does not scan its row
+type MatrixGraph struct {
+	// the vertexes and edges of the matrix, the adjacencies of V[i] are the scan of row i
+	// and the in-adjacencies the scan of column i
+	g Graph
+	// cells[i*n+j] is the first link of the edges going from V[i] to V[j], or -1
+	// the link 2*k stands for the edge E[k] in the cell of its endpoints in order,
+	// and the link 2*k+1 for E[k] in the other cell of an undirected edge
+	cells []int32
+	// the link following every link in its cell, or -1
+	next []int32
+}
+
+// returns an adjacency matrix copy of g, keeping its ids and payloads
+// the adjacencies of every vertex are ordered by the id of the vertex they lead to,
+// then by edge id. g must have fewer than 2^30 edge ids
+func NewMatrixGraph(g Interface) MatrixGraph {
+	m := MatrixGraph{g: skeleton(g)}
+	n := m.g.Order()
+	m.cells = make([]int32, n*n)
+	for i := range m.cells {
+		m.cells[i] = -1
+	}
+	m.next = make([]int32, 2*m.g.Size())
+	// edges are pushed in front of their cells from the last one, so every cell is ordered by id
+	entries := 0
+	for k := len(m.g.edges) - 1; k >= 0; k-- {
+		e := &m.g.edges[k]
+		if e.removed {
+			continue
+		}
+		vi, vj := e.Endpoints()
+		m.next[2*k], m.cells[vi*n+vj] = m.cells[vi*n+vj], int32(2*k)
+		entries++
+		if !m.g.directed && vi != vj {
+			m.next[2*k+1], m.cells[vj*n+vi] = m.cells[vj*n+vi], int32(2*k+1)
+			entries++
+		}
+	}
+	rows := make([]Adjacency, 0, entries)
+	for i := range m.g.vertexes {
+		start := len(rows)
+		for j := 0; j < n; j++ {
+			rows = m.appendCell(rows, i, j, j)
+		}
+		m.g.vertexes[i].out = rows[start:len(rows):len(rows)]
+	}
+	if !m.g.directed {
+		return m
+	}
+	columns := make([]Adjacency, 0, entries)
+	for i := range m.g.vertexes {
+		start := len(columns)
+		for j := 0; j < n; j++ {
+			columns = m.appendCell(columns, j, i, j)
+		}
+		m.g.vertexes[i].in = columns[start:len(columns):len(columns)]
+	}
+	return m
+}
+
+// appends the edges of the cell at row i and column j to list as adjacencies of endpoint
+func (m MatrixGraph) appendCell(list []Adjacency, i, j, endpoint int) []Adjacency {
+	for link := m.cells[i*m.g.Order()+j]; link != -1; link = m.next[link] {
+		list = append(list, Adjacency{endpoint, int(link / 2)})
+	}
+	return list
+}
+
+// returns true if the edges of this graph are directed
+func (m MatrixGraph) IsDirected() bool {
+	return m.g.directed
+}
+
+// returns the number of vertex ids of this graph
+func (m MatrixGraph) Order() int {
+	return m.g.Order()
+}
+
+// returns the number of edge ids of this graph
+func (m MatrixGraph) Size() int {
+	return m.g.Size()
+}
+
+// returns the number of vertexes of this graph, excluding removed ones
+func (m MatrixGraph) VertexCount() int {
+	return m.g.VertexCount()
+}
+
+// returns the number of edges of this graph, excluding removed ones
+func (m MatrixGraph) EdgeCount() int {
+	return m.g.EdgeCount()
+}
+
+// returns the ids of the edges going from V[vi] to V[vj] in increasing order
+// for undirected graphs the edges joining both vertexes
+func (m MatrixGraph) EdgesBetween(vi, vj int) ([]int, error) {
+	if err := m.g.testVertex(vi, vj); err != nil {
+		return nil, err
+	}
+	edges := make([]int, 0)
+	for link := m.cells[vi*m.g.Order()+vj]; link != -1; link = m.next[link] {
+		edges = append(edges, int(link/2))
+	}
+	return edges, nil
+}
+
+// returns true if an edge goes from V[vi] to V[vj], or joins them for undirected graphs
+func (m MatrixGraph) HasEdge(vi, vj int) bool {
+	return m.g.testVertex(vi, vj) == nil && m.cells[vi*m.g.Order()+vj] != -1
+}
+
+// returns the edges leaving vertexIndex and the vertexes they lead to
+// the returned array is shared with the graph and must not be modified
+func (m MatrixGraph) Adjacencies(vertexIndex int) ([]Adjacency, error) {
+	return m.g.GetAdjacencies(vertexIndex)
+}
+
+// returns the edges entering vertexIndex and the vertexes they come from
+// for undirected graphs this is the same as Adjacencies
+// the returned array is shared with the graph and must not be modified
+func (m MatrixGraph) InAdjacencies(vertexIndex int) ([]Adjacency, error) {
+	return m.g.GetInAdjacencies(vertexIndex)
+}
+
+// returns the ids of the vertexes joined by the edge E[edgeIndex]
+func (m MatrixGraph) Endpoints(edgeIndex int) (int, int, error) {
+	return m.g.Endpoints(edgeIndex)
+}
+
+// returns a pointer to the vertex V[vertexIndex]
+func (m MatrixGraph) GetVertex(vertexIndex int) (*Vertex, error) {
+	return m.g.GetVertex(vertexIndex)
+}
+
+// returns the Data of the vertex V[vertexIndex]
+func (m MatrixGraph) VertexData(vertexIndex int) (interface{}, error) {
+	return m.g.VertexData(vertexIndex)
+}
+
+// returns the Data of the edge E[edgeIndex]
+func (m MatrixGraph) EdgeData(edgeIndex int) (interface{}, error) {
+	return m.g.EdgeData(edgeIndex)
+}
+
+// returns the weight of the edge E[edgeIndex]
+func (m MatrixGraph) Weight(edgeIndex int) (float64, error) {
+	return m.g.Weight(edgeIndex)
+}
+
+// returns a pointer to the edge E[edgeIndex]
+func (m MatrixGraph) GetEdge(edgeIndex int) (*Edge, error) {
+	return m.g.GetEdge(edgeIndex)
+}
@@ -0,0 +1,145 @@
+package graph
+
+import (
+	"math"
+	"sort"
+)
+
+// a minimum spanning forest as computed by Kruskal or Prim
+// a spanning tree is returned for each connected component of the graph
+type SpanningForest struct {
+	// ids of the edges in the forest
+	Edges []int
+	// sum of the costs of the edges in the forest
+	Weight float64
+	// number of trees in the forest, 1 if the graph is connected
+	Trees int
+}
+
+// computes a minimum spanning forest using Kruskal's algorithm
+// edge costs are given by w, or by the edge weights if w is nil
+// an ErrDirected is returned for directed graphs
+func Kruskal(g Interface, w WeightFunc) (SpanningForest, error) {
+	if g.IsDirected() {
+		return SpanningForest{}, ErrDirected
+	}
+	costOf := edgeCosts(g, w)
+	costs := make([]float64, g.Size())
+	order := make([]int, g.Size())
+	for i := 0; i < g.Size(); i++ {
+		if edgeExists(g, i) {
+			costs[i] = costOf(i)
+		}
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return costs[order[i]] < costs[order[j]]
+	})
+	forest := SpanningForest{Edges: make([]int, 0), Trees: vertexCount(g)}
+	sets := newDisjointSet(g.Order())
+	for _, e := range order {
+		vi, vj, err := g.Endpoints(e)
+		if err != nil {
+			continue
+		}
+		if sets.union(vi, vj) {
+			forest.Edges = append(forest.Edges, e)
+			forest.Weight += costs[e]
+			forest.Trees--
+		}
+	}
+	return forest, nil
+}
+
+// computes a minimum spanning forest using Prim's algorithm
+// trees are grown from the lowest vertex id of each connected component
+// edge costs are given by w, or by the edge weights if w is nil
+// an ErrDirected is returned for directed graphs
+func Prim(g Interface, w WeightFunc) (SpanningForest, error) {
+	if g.IsDirected() {
+		return SpanningForest{}, ErrDirected
+	}
+	costOf := edgeCosts(g, w)
+	forest := SpanningForest{Edges: make([]int, 0)}
+	key := make([]float64, g.Order())
+	via := make([]int, g.Order())
+	done := make([]bool, g.Order())
+	for v := range key {
+		key[v] = math.Inf(1)
+		via[v] = -1
+	}
+	for root := 0; root < g.Order(); root++ {
+		if done[root] || !vertexExists(g, root) {
+			continue
+		}
+		forest.Trees++
+		key[root] = 0
+		queue := &pqueue{}
+		queue.push(root, 0)
+		for queue.Len() > 0 {
+			u := queue.pop().vertex
+			if done[u] {
+				continue
+			}
+			done[u] = true
+			if via[u] != -1 {
+				forest.Edges = append(forest.Edges, via[u])
+				forest.Weight += key[u]
+			}
+			for _, o := range adjacencies(g, u) {
+				if done[o.Endpoint] {
+					continue
+				}
+				if cost := costOf(o.Edge); cost < key[o.Endpoint] {
+					key[o.Endpoint] = cost
+					via[o.Endpoint] = o.Edge
+					queue.push(o.Endpoint, cost)
+				}
+			}
+		}
+	}
+	return forest, nil
+}
+
+// a union-find structure over the integers 0..n-1
+type disjointSet struct {
+	parent []int
+	rank   []int
+}
+
+func newDisjointSet(n int) *disjointSet {
+	s := &disjointSet{
+		parent: make([]int, n),
+		rank:   make([]int, n),
+	}
+	for i := range s.parent {
+		s.parent[i] = i
+	}
+	return s
+}
+
+// returns the representative of the set holding x
+func (s *disjointSet) find(x int) int {
+	for s.parent[x] != x {
+		s.parent[x] = s.parent[s.parent[x]]
+		x = s.parent[x]
+	}
+	return x
+}
+
+// merges the sets holding x and y
+// returns false if they already were the same set
+func (s *disjointSet) union(x, y int) bool {
+	x, y = s.find(x), s.find(y)
+	if x == y {
+		return false
+	}
+	if s.rank[x] < s.rank[y] {
+		x, y = y, x
+	}
+	s.parent[y] = x
+	if s.rank[x] == s.rank[y] {
+		s.rank[x]++
+	}
+	return true
+}
@@ -0,0 +1,31 @@
+package graph
+
+import "testing"
+
+// TestMinimumSpanningForest:
+// Verify Kruskal and Prim agree on a disconnected graph
+func TestMinimumSpanningForest(t *testing.T) {
+	g := Graph{}
+	for i := 0; i < 6; i++ {
+		g.AddVertex(i)
+	}
+	g.AddWeightedEdge(0, 1, 4, nil)
+	g.AddWeightedEdge(1, 2, 2, nil)
+	g.AddWeightedEdge(0, 2, 1, nil)
+	g.AddWeightedEdge(2, 3, 5, nil)
+	g.AddWeightedEdge(1, 3, 3, nil)
+	g.AddWeightedEdge(4, 5, 7, nil)
+	g.AddWeightedEdge(3, 3, 0, nil)
+	for name, mst := range map[string]func(Interface, WeightFunc) (SpanningForest, error){"Kruskal": Kruskal, "Prim": Prim} {
+		forest, err := mst(g, nil)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		if forest.Weight != 13 || forest.Trees != 2 || len(forest.Edges) != 4 {
+			t.Fatalf("%s(): expected 2 trees with 4 edges of weight 13, got %d trees with %v of weight %v.", name, forest.Trees, forest.Edges, forest.Weight)
+		}
+	}
+	if _, err := Kruskal(NewDigraph(), nil); err != ErrDirected {
+		t.Fatalf("Kruskal(): expected '%v' error got '%v'.", ErrDirected, err)
+	}
+}
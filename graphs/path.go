@@ -1,31 +1,123 @@
 package graph
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
 
-type out struct {
-	Endpoint int
-	Edge     int
+var ErrCompacted = fmt.Errorf("the graph was compacted")
+
+// returned by the operations of a path whose graph changed
+// in a way that the path is no longer valid
+// Cause is ErrCompacted, or the ErrRemoved of a vertex or edge of the path
+type ErrStalePath struct {
+	Cause error
+}
+
+func (err ErrStalePath) Error() string {
+	return fmt.Sprintf("path is no longer valid: %v", err.Cause)
+}
+
+func (err ErrStalePath) Unwrap() error {
+	return err.Cause
 }
 
 type Path struct {
-	graph *Graph
+	graph Interface
 	start int
-	out   []out
+	out   []Adjacency
+	// the version and compactions of the graph when the path was last validated
+	version     int
+	compactions int
+}
+
+// implemented by graphs that record the changes that can make their paths invalid,
+// see Graph.changed
+type versioned interface {
+	changes() (int, int)
+	graphIdentity() *identity
+}
+
+// returns a path over this graph holding the single vertex start
+// the path is bound to this graph: it sees the vertexes and edges added later,
+// and returns an ErrStalePath once a vertex or edge it uses is removed or the graph is compacted
+func (g *Graph) NewPath(start int) (Path, error) {
+	return NewPath(g, start)
 }
 
-func (g Graph) NewPath(start int) (Path, error) {
+// returns a path over g holding the single vertex start
+// as for the paths returned by the algorithms of this package, if g is a *Graph
+// the path is bound to it, see Graph.NewPath, while a Graph value binds the path
+// to a copy that does not see the vertexes and edges added later
+func NewPath(g Interface, start int) (Path, error) {
 	var p Path
-	if len(g.vertexes) == 0 {
+	if g.Order() == 0 {
 		return p, ErrEmptyGraph
 	}
-	if err := g.testVertex(start); err != nil {
+	if err := testVertex(g, start); err != nil {
 		return p, err
 	}
-	p.graph = &g
+	p.graph = g
 	p.start = start
+	if v, ok := g.(versioned); ok {
+		p.version, p.compactions = v.changes()
+	}
 	return p, nil
 }
 
+// returns the identity of the graph of this path, or nil if its graph has none
+func (p Path) identity() *identity {
+	if v, ok := p.graph.(versioned); ok {
+		return v.graphIdentity()
+	}
+	return nil
+}
+
+// returns an error if this path can no longer be used with its graph:
+// ErrNoGraph if it has no graph, or an ErrStalePath if the graph was compacted
+// or a vertex or edge of the path was removed since the path was built
+// adding vertexes and edges to the graph keeps its paths valid
+// the vertexes and edges of the path are only checked if something was removed from the graph
+func (p Path) Validate() error {
+	if p.graph == nil {
+		return ErrNoGraph
+	}
+	v, ok := p.graph.(versioned)
+	if !ok {
+		return nil
+	}
+	version, compactions := v.changes()
+	if compactions != p.compactions {
+		return ErrStalePath{ErrCompacted}
+	}
+	if version == p.version {
+		return nil
+	}
+	if err := testVertex(p.graph, p.start); err != nil {
+		return ErrStalePath{err}
+	}
+	for _, o := range p.out {
+		if _, _, err := p.graph.Endpoints(o.Edge); err != nil {
+			return ErrStalePath{err}
+		}
+		if err := testVertex(p.graph, o.Endpoint); err != nil {
+			return ErrStalePath{err}
+		}
+	}
+	return nil
+}
+
+// wraps the ErrRemoved of a vertex or edge of this path in an ErrStalePath
+// the removal can have been made through a copy of the graph of the path,
+// which Validate does not see
+func stale(err error) error {
+	var removed ErrRemoved
+	if errors.As(err, &removed) {
+		return ErrStalePath{err}
+	}
+	return err
+}
+
 func (p Path) GetLastVertex() int {
 	if len(p.out) == 0 {
 		return p.start
@@ -34,17 +126,18 @@ func (p Path) GetLastVertex() int {
 }
 
 func (p Path) Grow(vertexIndex, edgeIndex int) (Path, error) {
-	if p.graph == nil {
-		return p, ErrNoGraph
+	if err := p.Validate(); err != nil {
+		return p, err
 	}
-	if err := p.graph.testVertex(vertexIndex); err != nil {
+	if err := testVertex(p.graph, vertexIndex); err != nil {
 		return p, err
 	}
-	if err := p.graph.testEdge(edgeIndex); err != nil {
+	vi, vj, err := p.graph.Endpoints(edgeIndex)
+	if err != nil {
 		return p, err
 	}
 
-	endpoints := p.graph.edges[edgeIndex].endpoints
+	endpoints := []int{vi, vj}
 	if endpoints[0] != vertexIndex && endpoints[1] != vertexIndex {
 		return p, fmt.Errorf("edge: %d is not incident to vertex: %d", edgeIndex, vertexIndex)
 	}
@@ -52,15 +145,36 @@ func (p Path) Grow(vertexIndex, edgeIndex int) (Path, error) {
 	if endpoints[0] != last && endpoints[1] != last {
 		return p, fmt.Errorf("edge: %d is not incident to vertex: %d", edgeIndex, last)
 	}
+	if p.graph.IsDirected() {
+		if endpoints[0] != last || endpoints[1] != vertexIndex {
+			return p, fmt.Errorf("edge: %d does not go from vertex: %d to vertex: %d", edgeIndex, last, vertexIndex)
+		}
+	} else if (endpoints[0] != last || endpoints[1] != vertexIndex) && (endpoints[1] != last || endpoints[0] != vertexIndex) {
+		return p, fmt.Errorf("edge: %d does not join vertex: %d with vertex: %d", edgeIndex, last, vertexIndex)
+	}
 
-	p.out = append(p.out, out{
+	p.out = append(p.out, Adjacency{
 		Edge:     edgeIndex,
 		Endpoint: vertexIndex,
 	})
+	if v, ok := p.graph.(versioned); ok {
+		p.version, _ = v.changes()
+	}
+	return p, nil
+}
+
+// grows this path with each of the given steps
+func (p Path) growSteps(steps []Adjacency) (Path, error) {
+	var err error
+	for _, step := range steps {
+		if p, err = p.Grow(step.Endpoint, step.Edge); err != nil {
+			return p, err
+		}
+	}
 	return p, nil
 }
 
-func printPath(vi, vj *vertex, e *edge) {
+func printPath(vi, vj *Vertex, e *Edge) {
 	if e == nil {
 		fmt.Printf("v%d\n", vi.id)
 	} else {
@@ -72,29 +186,271 @@ func (p Path) PrintPath() {
 	p.TraversePath(printPath)
 }
 
-func (p Path) TraversePath(fn func(vi, vj *vertex, e *edge)) error {
-	if p.graph == nil {
-		return fmt.Errorf("no graph associated with this path")
+// calls fn for every step of this path, vj and e being the vertex and edge reached by the step
+// for a path without edges fn is called once with its only vertex and nil vj and e
+func (p Path) TraversePath(fn func(vi, vj *Vertex, e *Edge)) error {
+	steps := p.Steps()
+	for steps.Next() {
+		fn(steps.Step())
 	}
-	vi, err := p.graph.GetVertex(p.start)
-	if err != nil {
-		return err
+	return steps.Err()
+}
+
+// same as TraversePath but the walk ends as soon as fn returns an error,
+// which is then returned. if the error is ErrStop nil is returned instead
+func (p Path) WalkPath(fn func(vi, vj *Vertex, e *Edge) error) error {
+	steps := p.Steps()
+	for steps.Next() {
+		if err := fn(steps.Step()); err != nil {
+			return stopped(err)
+		}
+	}
+	return steps.Err()
+}
+
+// iterates over the steps of a path, see Path.Steps
+type PathIterator struct {
+	path   Path
+	next   int
+	vi, vj *Vertex
+	e      *Edge
+	err    error
+}
+
+// returns an iterator over the steps of this path, used as:
+//
+//	steps := p.Steps()
+//	for steps.Next() {
+//		vi, vj, e := steps.Step()
+//	}
+//	err := steps.Err()
+//
+// the steps are the ones passed to the callback of TraversePath
+func (p Path) Steps() *PathIterator {
+	return &PathIterator{path: p}
+}
+
+// moves to the next step of the path
+// returns false when there are no more steps or an error happened, see Err
+func (it *PathIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	p := it.path
+	if it.next == 0 {
+		if it.err = p.Validate(); it.err != nil {
+			return false
+		}
+		if it.vj, it.err = getVertex(p.graph, p.start); it.err != nil {
+			it.err = stale(it.err)
+			return false
+		}
+	}
+	if it.next == 0 && len(p.out) == 0 {
+		it.vi, it.vj, it.e = it.vj, nil, nil
+		it.next++
+		return true
+	}
+	if it.next >= len(p.out) {
+		return false
+	}
+	o := p.out[it.next]
+	it.vi = it.vj
+	if it.e, it.err = getEdge(p.graph, o.Edge); it.err != nil {
+		it.err = stale(it.err)
+		return false
+	}
+	if it.vj, it.err = getVertex(p.graph, o.Endpoint); it.err != nil {
+		it.err = stale(it.err)
+		return false
+	}
+	it.next++
+	return true
+}
+
+// returns the vertex the current step leaves, the vertex it reaches and the edge walked
+func (it *PathIterator) Step() (vi, vj *Vertex, e *Edge) {
+	return it.vi, it.vj, it.e
+}
+
+// returns the error that ended the iteration, or nil if all the steps were visited
+func (it *PathIterator) Err() error {
+	return it.err
+}
+
+// returns the number of edges of this path
+func (p Path) Length() int {
+	return len(p.out)
+}
+
+// returns the ids of the vertexes of this path in order, Length()+1 of them
+// vertexes visited several times appear once per visit
+func (p Path) Vertices() []int {
+	vertexes := make([]int, len(p.out)+1)
+	vertexes[0] = p.start
+	for i, o := range p.out {
+		vertexes[i+1] = o.Endpoint
+	}
+	return vertexes
+}
+
+// returns the ids of the edges of this path in order
+func (p Path) Edges() []int {
+	edges := make([]int, len(p.out))
+	for i, o := range p.out {
+		edges[i] = o.Edge
+	}
+	return edges
+}
+
+// returns true if both paths are over graphs that have an identity and these graphs differ
+func differentGraphs(p, q Path) bool {
+	ip, iq := p.identity(), q.identity()
+	return ip != nil && iq != nil && ip != iq
+}
+
+// returns the path that follows this path and then q
+// q must start at the last vertex of this path and its steps must be valid in the graph of this path
+func (p Path) Concat(q Path) (Path, error) {
+	if err := p.Validate(); err != nil {
+		return p, err
+	}
+	if err := q.Validate(); err != nil {
+		return p, err
+	}
+	if differentGraphs(p, q) {
+		return p, fmt.Errorf("paths belong to different graphs")
+	}
+	if q.start != p.GetLastVertex() {
+		return p, fmt.Errorf("path ends at vertex: %d but the next one starts at vertex: %d", p.GetLastVertex(), q.start)
+	}
+	p.out = append(make([]Adjacency, 0, len(p.out)+len(q.out)), p.out...)
+	return p.growSteps(q.out)
+}
+
+// returns this path walked from its last vertex to its first one
+// returns ErrDirected for paths of directed graphs with edges, which cannot be walked backwards
+func (p Path) Reverse() (Path, error) {
+	if err := p.Validate(); err != nil {
+		return p, err
 	}
 	if len(p.out) == 0 {
-		fn(vi, nil, nil)
-		return nil
+		return p, nil
+	}
+	if p.graph.IsDirected() {
+		return p, ErrDirected
+	}
+	vertexes := p.Vertices()
+	r := p
+	r.start = p.GetLastVertex()
+	r.out = make([]Adjacency, len(p.out))
+	for i := range p.out {
+		k := len(p.out) - 1 - i
+		r.out[i] = Adjacency{vertexes[k], p.out[k].Edge}
+	}
+	return r, nil
+}
+
+// returns the part of this path between its i-th and j-th vertexes, both included
+// vertexes are numbered from 0 to Length() as returned by Vertices
+// returns an error if 0 <= i <= j <= Length() does not hold
+func (p Path) SubPath(i, j int) (Path, error) {
+	if err := p.Validate(); err != nil {
+		return p, err
+	}
+	if i < 0 || i > len(p.out) {
+		return p, ErrOutOfBounds{"path vertex", i}
+	}
+	if j < i || j > len(p.out) {
+		return p, ErrOutOfBounds{"path vertex", j}
+	}
+	s := p
+	s.start = p.Vertices()[i]
+	s.out = append(make([]Adjacency, 0, j-i), p.out[i:j]...)
+	return s, nil
+}
+
+// returns true if this path visits V[vertexIndex]
+// a path without a graph contains no vertex
+func (p Path) ContainsVertex(vertexIndex int) bool {
+	if p.graph == nil {
+		return false
+	}
+	if p.start == vertexIndex {
+		return true
 	}
-	for _, v := range p.out {
-		edge, err := p.graph.GetEdge(v.Edge)
-		if err != nil {
-			return err
+	for _, o := range p.out {
+		if o.Endpoint == vertexIndex {
+			return true
 		}
-		vj, err := p.graph.GetVertex(v.Endpoint)
-		if err != nil {
-			return err
+	}
+	return false
+}
+
+// returns true if this path walks the edge E[edgeIndex]
+// a path without a graph contains no edge
+func (p Path) ContainsEdge(edgeIndex int) bool {
+	if p.graph == nil {
+		return false
+	}
+	for _, o := range p.out {
+		if o.Edge == edgeIndex {
+			return true
 		}
-		fn(vi, vj, edge)
-		vi = vj
 	}
-	return nil
+	return false
+}
+
+// returns true if this path ends at the vertex it starts from
+// a path without edges is closed
+func (p Path) IsClosed() bool {
+	return p.start == p.GetLastVertex()
+}
+
+// returns true if this path does not visit any vertex twice,
+// except for a closed path ending at its first vertex
+// a closed path with edges that is both simple and a trail is a cycle
+func (p Path) IsSimple() bool {
+	vertexes := p.Vertices()
+	if len(p.out) > 0 && p.IsClosed() {
+		vertexes = vertexes[1:]
+	}
+	visited := make(map[int]bool, len(vertexes))
+	for _, v := range vertexes {
+		if visited[v] {
+			return false
+		}
+		visited[v] = true
+	}
+	return true
+}
+
+// returns true if this path does not walk any edge twice
+func (p Path) IsTrail() bool {
+	walked := make(map[int]bool, len(p.out))
+	for _, o := range p.out {
+		if walked[o.Edge] {
+			return false
+		}
+		walked[o.Edge] = true
+	}
+	return true
+}
+
+// returns true if both paths start at the same vertex and walk the same edges
+// to the same vertexes in the same order
+// paths over graphs known to be different are never equal
+func (p Path) Equal(q Path) bool {
+	if (p.graph == nil) != (q.graph == nil) || differentGraphs(p, q) {
+		return false
+	}
+	if p.start != q.start || len(p.out) != len(q.out) {
+		return false
+	}
+	for i := range p.out {
+		if p.out[i] != q.out[i] {
+			return false
+		}
+	}
+	return true
 }
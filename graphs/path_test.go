@@ -0,0 +1,330 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// TestPathSeesNewElements:
+// Verify a path is bound to the live graph and sees vertexes and edges added later
+func TestPathSeesNewElements(t *testing.T) {
+	g := Graph{}
+	g.AddVertex(0)
+	g.AddVertex(1)
+	g.AddEdge(0, 1, nil)
+	path, _ := g.NewPath(0)
+	path, _ = path.Grow(1, 0)
+	g.AddVertex(2)
+	e, _ := g.AddEdge(1, 2, nil)
+	path, err := path.Grow(2, e)
+	if err != nil || path.GetLastVertex() != 2 {
+		t.Fatalf("path.Grow(2, %d): expected to reach the new vertex, got %v.", e, err)
+	}
+	g.SetWeight(0, 5)
+	if w, err := path.Weight(); err != nil || w != 6 {
+		t.Fatalf("path.Weight(): expected value 6, got %v (%v).", w, err)
+	}
+}
+
+// TestPathInvalidation:
+// Verify operations on a path fail once the graph removes its elements or is compacted
+func TestPathInvalidation(t *testing.T) {
+	g := Graph{}
+	for i := 0; i < 4; i++ {
+		g.AddVertex(i)
+	}
+	g.AddEdge(0, 1, nil)
+	g.AddEdge(1, 2, nil)
+	g.AddEdge(2, 3, nil)
+	path, _ := g.NewPath(0)
+	path, _ = path.Grow(1, 0)
+	path, _ = path.Grow(2, 1)
+
+	// removing an element the path does not use keeps it valid
+	g.RemoveEdge(2)
+	if err := path.Validate(); err != nil {
+		t.Fatalf("path.Validate(): expected a valid path, got %v.", err)
+	}
+	g.RemoveVertex(3)
+	if _, err := path.Weight(); err != nil {
+		t.Fatalf("path.Weight(): expected a valid path, got %v.", err)
+	}
+
+	g.RemoveEdge(1)
+	var stale ErrStalePath
+	var removed ErrRemoved
+	if _, err := path.Weight(); !errors.As(err, &stale) || !errors.As(err, &removed) || removed.Index != 1 {
+		t.Fatalf("path.Weight(): expected a stale path using removed edge 1, got %v.", err)
+	}
+	if err := path.TraversePath(func(vi, vj *Vertex, e *Edge) {}); !errors.As(err, &stale) {
+		t.Fatalf("path.TraversePath(): expected a stale path, got %v.", err)
+	}
+	if _, err := path.Grow(0, 0); !errors.As(err, &stale) {
+		t.Fatalf("path.Grow(0, 0): expected a stale path, got %v.", err)
+	}
+
+	short, _ := g.NewPath(0)
+	short, _ = short.Grow(1, 0)
+	g.Compact()
+	if err := short.Validate(); !errors.Is(err, ErrCompacted) {
+		t.Fatalf("path.Validate(): expected ErrCompacted, got %v.", err)
+	}
+}
+
+// TestPathOverCopies:
+// Verify the paths of an algorithm run on a *Graph notice removals made through copies of it
+func TestPathOverCopies(t *testing.T) {
+	g := Graph{}
+	g.AddVertex(0)
+	g.AddVertex(1)
+	g.AddEdge(0, 1, nil)
+	sp, err := Dijkstra(&g, 0, nil)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	path, _ := sp.PathTo(1)
+	h := g
+	h.RemoveEdge(0)
+	var stale ErrStalePath
+	if _, err := path.Weight(); !errors.As(err, &stale) {
+		t.Fatalf("path.Weight(): expected a stale path after removing edge 0, got %v.", err)
+	}
+}
+
+// TestPathSurvivesGrowth:
+// Verify paths stay valid when vertexes and edges are added, through the graph or a copy of it
+func TestPathSurvivesGrowth(t *testing.T) {
+	g := NewGraph()
+	g.AddVertex(0)
+	g.AddVertex(1)
+	g.AddEdge(0, 1, nil)
+	live, _ := g.NewPath(0)
+	h := g
+	h.AddVertex(2)
+	h.AddEdge(1, 2, nil)
+	if err := live.Validate(); err != nil {
+		t.Fatalf("path.Validate(): expected a valid path after growing a copy, got %v.", err)
+	}
+
+	// the results of an algorithm run on a Graph value keep working after it grows
+	traced, _ := Trace(g, 0)
+	sp, _ := Dijkstra(g, 0, nil)
+	shortest, _ := sp.PathTo(1)
+	circuit, _ := g.NewPath(0)
+	g.AddVertex(2)
+	e, _ := g.AddEdge(1, 2, nil)
+	for _, p := range []Path{live, traced, shortest, circuit} {
+		if err := p.Validate(); err != nil {
+			t.Fatalf("path.Validate(): expected a valid path after adding to the graph, got %v.", err)
+		}
+		if w, err := p.Weight(); err != nil || w != float64(p.Length()) {
+			t.Fatalf("path.Weight(): expected value %d, got %v (%v).", p.Length(), w, err)
+		}
+	}
+
+	// results of an algorithm run on a *Graph are bound to the live graph
+	sp, _ = Dijkstra(&g, 0, nil)
+	bound, _ := sp.PathTo(1)
+	g.AddVertex(3)
+	last, _ := g.AddEdge(2, 3, nil)
+	if bound, err := bound.Grow(2, e); err != nil {
+		t.Fatalf("path.Grow(2, %d): expected to reach the new vertex, got %v.", e, err)
+	} else if _, err := bound.Grow(3, last); err != nil {
+		t.Fatalf("path.Grow(3, %d): expected to reach the new vertex, got %v.", last, err)
+	}
+	g.RemoveEdge(0)
+	var stale ErrStalePath
+	if err := bound.Validate(); !errors.As(err, &stale) {
+		t.Fatalf("path.Validate(): expected a stale path after removing edge 0, got %v.", err)
+	}
+}
+
+// returns the path of g through the given vertexes, joined by the given edges
+func walk(t *testing.T, g *Graph, start int, steps ...Adjacency) Path {
+	path, err := g.NewPath(start)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if path, err = path.growSteps(steps); err != nil {
+		t.Fatalf(err.Error())
+	}
+	return path
+}
+
+// TestPathQueries:
+// Verify the vertexes, edges and properties of a path
+func TestPathQueries(t *testing.T) {
+	g := Graph{}
+	for i := 0; i < 4; i++ {
+		g.AddVertex(i)
+	}
+	g.AddEdge(0, 1, nil)
+	g.AddEdge(1, 2, nil)
+	g.AddEdge(2, 0, nil)
+	g.AddEdge(2, 3, nil)
+	g.AddEdge(1, 0, nil)
+	cycle := walk(t, &g, 0, Adjacency{1, 0}, Adjacency{2, 1}, Adjacency{0, 2})
+	if cycle.Length() != 3 || fmt.Sprint(cycle.Vertices()) != "[0 1 2 0]" || fmt.Sprint(cycle.Edges()) != "[0 1 2]" {
+		t.Fatalf("unexpected cycle %v %v.", cycle.Vertices(), cycle.Edges())
+	}
+	if !cycle.IsClosed() || !cycle.IsSimple() || !cycle.IsTrail() {
+		t.Fatalf("expected a closed, simple trail.")
+	}
+	if !cycle.ContainsVertex(2) || cycle.ContainsVertex(3) || !cycle.ContainsEdge(2) || cycle.ContainsEdge(3) {
+		t.Fatalf("unexpected ContainsVertex or ContainsEdge.")
+	}
+	back := walk(t, &g, 0, Adjacency{1, 0}, Adjacency{0, 0})
+	if !back.IsClosed() || !back.IsSimple() || back.IsTrail() {
+		t.Fatalf("expected an edge walked twice not to be a trail.")
+	}
+	parallel := walk(t, &g, 0, Adjacency{1, 0}, Adjacency{0, 4})
+	if !parallel.IsSimple() || !parallel.IsTrail() {
+		t.Fatalf("expected parallel edges to form a cycle.")
+	}
+	eight := walk(t, &g, 3, Adjacency{2, 3}, Adjacency{0, 2}, Adjacency{1, 0}, Adjacency{2, 1})
+	if eight.IsClosed() || eight.IsSimple() || !eight.IsTrail() {
+		t.Fatalf("expected an open trail visiting vertex 2 twice.")
+	}
+	if single := walk(t, &g, 3); single.Length() != 0 || !single.IsClosed() || !single.IsSimple() || !single.ContainsVertex(3) {
+		t.Fatalf("unexpected single vertex path %v.", single.Vertices())
+	}
+	if (Path{}).ContainsVertex(0) || (Path{out: []Adjacency{{1, 0}}}).ContainsEdge(0) {
+		t.Fatalf("expected a path without a graph to contain no vertex or edge.")
+	}
+}
+
+// TestPathAlgebra:
+// Verify paths are concatenated, reversed, sliced and compared
+func TestPathAlgebra(t *testing.T) {
+	g := Graph{}
+	for i := 0; i < 4; i++ {
+		g.AddVertex(i)
+	}
+	g.AddEdge(0, 1, nil)
+	g.AddEdge(1, 2, nil)
+	g.AddEdge(3, 2, nil)
+	first := walk(t, &g, 0, Adjacency{1, 0})
+	second := walk(t, &g, 1, Adjacency{2, 1}, Adjacency{3, 2})
+	whole, err := first.Concat(second)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if fmt.Sprint(whole.Vertices()) != "[0 1 2 3]" || first.Length() != 1 {
+		t.Fatalf("Concat(): unexpected path %v, first %v.", whole.Vertices(), first.Vertices())
+	}
+	if _, err := second.Concat(first); err == nil {
+		t.Fatalf("Concat(): expected an error joining paths that do not meet.")
+	}
+	reversed, err := whole.Reverse()
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if fmt.Sprint(reversed.Vertices()) != "[3 2 1 0]" || fmt.Sprint(reversed.Edges()) != "[2 1 0]" {
+		t.Fatalf("Reverse(): unexpected path %v %v.", reversed.Vertices(), reversed.Edges())
+	}
+	if twice, _ := reversed.Reverse(); !twice.Equal(whole) || reversed.Equal(whole) {
+		t.Fatalf("Reverse(): expected reversing twice to give the same path.")
+	}
+	middle, err := whole.SubPath(1, 3)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if !middle.Equal(second) {
+		t.Fatalf("SubPath(1, 3): expected %v, got %v.", second.Vertices(), middle.Vertices())
+	}
+	if point, _ := whole.SubPath(2, 2); point.Length() != 0 || point.GetLastVertex() != 2 {
+		t.Fatalf("SubPath(2, 2): expected the single vertex 2, got %v.", point.Vertices())
+	}
+	var outOfBounds ErrOutOfBounds
+	if _, err := whole.SubPath(2, 4); !errors.As(err, &outOfBounds) {
+		t.Fatalf("SubPath(2, 4): expected ErrOutOfBounds, got %v.", err)
+	}
+	other := Graph{}
+	for i := 0; i < 4; i++ {
+		other.AddVertex(i)
+	}
+	other.AddEdge(0, 1, nil)
+	if walk(t, &other, 0, Adjacency{1, 0}).Equal(first) {
+		t.Fatalf("Equal(): expected paths of different graphs to differ.")
+	}
+
+	d := NewDigraph()
+	d.AddVertex(0)
+	d.AddVertex(1)
+	d.AddEdge(0, 1, nil)
+	if _, err := walk(t, &d, 0, Adjacency{1, 0}).Reverse(); err != ErrDirected {
+		t.Fatalf("Reverse(): expected ErrDirected, got %v.", err)
+	}
+}
+
+// TestWalkPath:
+// Verify WalkPath stops early on ErrStop and returns the other errors of the callback
+func TestWalkPath(t *testing.T) {
+	g := Graph{}
+	for i := 0; i < 4; i++ {
+		g.AddVertex(i)
+	}
+	g.AddEdge(0, 1, nil)
+	g.AddEdge(1, 2, nil)
+	g.AddEdge(2, 3, nil)
+	path := walk(t, &g, 0, Adjacency{1, 0}, Adjacency{2, 1}, Adjacency{3, 2})
+	visited := make([]int, 0)
+	err := path.WalkPath(func(vi, vj *Vertex, e *Edge) error {
+		visited = append(visited, vj.ID())
+		if vj.ID() == 2 {
+			return ErrStop
+		}
+		return nil
+	})
+	if err != nil || fmt.Sprint(visited) != "[1 2]" {
+		t.Fatalf("WalkPath(): expected to stop at vertex 2 without error, got %v (%v).", visited, err)
+	}
+	failure := fmt.Errorf("failure")
+	err = path.WalkPath(func(vi, vj *Vertex, e *Edge) error {
+		return failure
+	})
+	if err != failure {
+		t.Fatalf("WalkPath(): expected the error of the callback, got %v.", err)
+	}
+	g.RemoveEdge(2)
+	var stale ErrStalePath
+	if err := path.WalkPath(func(vi, vj *Vertex, e *Edge) error { return nil }); !errors.As(err, &stale) {
+		t.Fatalf("WalkPath(): expected a stale path, got %v.", err)
+	}
+}
+
+// TestPathSteps:
+// Verify the iterator visits the same steps as TraversePath
+func TestPathSteps(t *testing.T) {
+	g := NewDigraph()
+	for i := 0; i < 3; i++ {
+		g.AddVertex(i)
+	}
+	g.AddEdge(0, 1, "a")
+	g.AddEdge(1, 2, "b")
+	path := walk(t, &g, 0, Adjacency{1, 0}, Adjacency{2, 1})
+	expected := make([]string, 0)
+	path.TraversePath(func(vi, vj *Vertex, e *Edge) {
+		expected = append(expected, fmt.Sprint(vi.ID(), vj.ID(), e.Data))
+	})
+	got := make([]string, 0)
+	steps := path.Steps()
+	for steps.Next() {
+		vi, vj, e := steps.Step()
+		got = append(got, fmt.Sprint(vi.ID(), vj.ID(), e.Data))
+	}
+	if steps.Err() != nil || fmt.Sprint(got) != fmt.Sprint(expected) || len(got) != 2 {
+		t.Fatalf("Steps(): expected %v, got %v (%v).", expected, got, steps.Err())
+	}
+	single := walk(t, &g, 2).Steps()
+	if !single.Next() {
+		t.Fatalf("Steps(): expected one step for a path without edges.")
+	}
+	if vi, vj, e := single.Step(); vi.ID() != 2 || vj != nil || e != nil || single.Next() {
+		t.Fatalf("Steps(): expected only vertex 2, got %v %v %v.", vi, vj, e)
+	}
+	if steps := (Path{}).Steps(); steps.Next() || steps.Err() != ErrNoGraph {
+		t.Fatalf("Steps(): expected ErrNoGraph, got %v.", steps.Err())
+	}
+}
@@ -0,0 +1,115 @@
+package graph
+
+import (
+	"fmt"
+	"math"
+)
+
+// returns a closed path of minimum cost that traverses every edge of g at least once
+// edge costs are given by w, or by the edge weights if w is nil, and must not be negative
+// odd vertexes are paired by a minimum cost matching, the shortest paths between
+// paired vertexes are duplicated and an Eulerian circuit of the result is computed
+// returns the path and its total cost
+func ChinesePostman(g Interface, w WeightFunc) (Path, float64, error) {
+	if g.IsDirected() {
+		return Path{}, 0, fmt.Errorf("chinese postman: directed graphs are not supported")
+	}
+	if vertexCount(g) == 0 {
+		return Path{}, 0, ErrEmptyGraph
+	}
+	costOf := edgeCosts(g, w)
+	odd := make([]int, 0)
+	for i := 0; i < g.Order(); i++ {
+		if degree(g, i)%2 == 1 {
+			odd = append(odd, i)
+		}
+	}
+	trees := make([]ShortestPaths, len(odd))
+	for i, v := range odd {
+		sp, err := Dijkstra(g, v, w)
+		if err != nil {
+			return Path{}, 0, err
+		}
+		trees[i] = sp
+	}
+	pairs, err := minCostPairing(len(odd), func(i, j int) float64 {
+		return trees[i].Distance(odd[j])
+	})
+	if err != nil {
+		return Path{}, 0, err
+	}
+
+	// every edge of the augmented graph holds the id of the edge of g it stands for
+	augmented := NewGraph()
+	for i := 0; i < g.Order(); i++ {
+		augmented.AddVertex(i)
+	}
+	for i := 0; i < g.Size(); i++ {
+		if vi, vj, err := g.Endpoints(i); err == nil {
+			augmented.AddEdge(vi, vj, i)
+		}
+	}
+	for _, pair := range pairs {
+		path, err := trees[pair[0]].PathTo(odd[pair[1]])
+		if err != nil {
+			return Path{}, 0, err
+		}
+		for _, o := range path.out {
+			vi, vj, _ := g.Endpoints(o.Edge)
+			augmented.AddEdge(vi, vj, o.Edge)
+		}
+	}
+	circuit, err := EulerCircuit(augmented)
+	if err != nil {
+		return Path{}, 0, err
+	}
+
+	steps := make([]Adjacency, len(circuit.out))
+	cost := 0.0
+	for i, o := range circuit.out {
+		edge := augmented.edges[o.Edge].Data.(int)
+		steps[i] = Adjacency{o.Endpoint, edge}
+		cost += costOf(edge)
+	}
+	path, err := NewPath(g, circuit.start)
+	if err != nil {
+		return path, 0, err
+	}
+	path, err = path.growSteps(steps)
+	return path, cost, err
+}
+
+// pairs up k items minimizing the sum of cost(i, j) over the pairs
+// through a maximum weight perfect matching of the complete graph over the items,
+// in O(k^3) time
+// returns an error if k is odd or no pairing of finite cost exists
+func minCostPairing(k int, cost func(i, j int) float64) ([][2]int, error) {
+	if k%2 == 1 {
+		return nil, fmt.Errorf("cannot pair an odd number of items: %d", k)
+	}
+	edges := make([]matchingEdge, 0, k*(k-1)/2)
+	maxCost := 0.0
+	for i := 0; i < k; i++ {
+		for j := i + 1; j < k; j++ {
+			if c := cost(i, j); !math.IsInf(c, 1) {
+				edges = append(edges, matchingEdge{i, j, c})
+				maxCost = math.Max(maxCost, c)
+			}
+		}
+	}
+	// every perfect matching has k/2 edges, so the heaviest one has the least cost
+	for e := range edges {
+		edges[e].weight = maxCost + 1 - edges[e].weight
+	}
+	mates := maxWeightMatching(k, edges)
+	pairs := make([][2]int, 0, k/2)
+	for i, j := range mates {
+		if j == -1 {
+			return nil, fmt.Errorf("odd vertexes cannot be paired, the graph is not connected")
+		}
+		if i < j {
+			pairs = append(pairs, [2]int{i, j})
+		}
+	}
+	return pairs, nil
+}
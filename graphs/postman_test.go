@@ -0,0 +1,143 @@
+package graph
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// TestChinesePostman:
+// Verify every edge is traversed and the cheapest edges are repeated
+func TestChinesePostman(t *testing.T) {
+	g := Graph{}
+	for i := 0; i < 4; i++ {
+		g.AddVertex(i)
+	}
+	// a square with one diagonal, vertexes 0 and 2 have odd degree
+	g.AddWeightedEdge(0, 1, 1, nil)
+	g.AddWeightedEdge(1, 2, 1, nil)
+	g.AddWeightedEdge(2, 3, 2, nil)
+	g.AddWeightedEdge(3, 0, 2, nil)
+	g.AddWeightedEdge(0, 2, 5, nil)
+	path, cost, err := ChinesePostman(g, nil)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if cost != 13 {
+		t.Fatalf("ChinesePostman(): expected cost 13, got %v.", cost)
+	}
+	if w, _ := path.Weight(); w != cost {
+		t.Fatalf("path.Weight(): expected value %v, got %v.", cost, w)
+	}
+	if path.GetLastVertex() != path.start {
+		t.Fatalf("ChinesePostman(): expected a closed path")
+	}
+	used := make([]int, g.Size())
+	for _, o := range path.out {
+		used[o.Edge]++
+	}
+	for e, n := range used {
+		if n == 0 {
+			t.Fatalf("edge: %d was not traversed.", e)
+		}
+	}
+}
+
+// TestMinCostPairing:
+// Verify pairings against every pairing of small random instances, with missing pairs
+func TestMinCostPairing(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for round := 0; round < 300; round++ {
+		k := 2 * (1 + r.Intn(5))
+		cost := make([][]float64, k)
+		for i := range cost {
+			cost[i] = make([]float64, k)
+		}
+		for i := 0; i < k; i++ {
+			for j := i + 1; j < k; j++ {
+				c := float64(r.Intn(20))
+				if round%2 == 1 {
+					c = r.Float64() * 10
+				}
+				if r.Intn(5) == 0 {
+					c = math.Inf(1)
+				}
+				cost[i][j], cost[j][i] = c, c
+			}
+		}
+		expected := cheapestPairing(cost, make([]bool, k))
+		pairs, err := minCostPairing(k, func(i, j int) float64 { return cost[i][j] })
+		if math.IsInf(expected, 1) {
+			if err == nil {
+				t.Fatalf("minCostPairing(): expected an error for %v.", cost)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		seen := make([]bool, k)
+		total := 0.0
+		for _, pair := range pairs {
+			if seen[pair[0]] || seen[pair[1]] {
+				t.Fatalf("minCostPairing(): item paired twice in %v.", pairs)
+			}
+			seen[pair[0]], seen[pair[1]] = true, true
+			total += cost[pair[0]][pair[1]]
+		}
+		if len(pairs) != k/2 || math.Abs(total-expected) > 1e-9 {
+			t.Fatalf("minCostPairing(): expected cost %v, got %v with %v.", expected, total, pairs)
+		}
+	}
+}
+
+// returns the least cost of pairing the items not yet paired by trying every pairing
+func cheapestPairing(cost [][]float64, paired []bool) float64 {
+	i := 0
+	for i < len(paired) && paired[i] {
+		i++
+	}
+	if i == len(paired) {
+		return 0
+	}
+	best := math.Inf(1)
+	paired[i] = true
+	for j := i + 1; j < len(paired); j++ {
+		if !paired[j] {
+			paired[j] = true
+			best = math.Min(best, cost[i][j]+cheapestPairing(cost, paired))
+			paired[j] = false
+		}
+	}
+	paired[i] = false
+	return best
+}
+
+// TestChinesePostmanManyOddVertexes:
+// Verify a graph with more odd vertexes than pairings can be enumerated for
+func TestChinesePostmanManyOddVertexes(t *testing.T) {
+	g, err := RandomRegular(200, 3, 1)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	path, cost, err := ChinesePostman(g, nil)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	// every vertex is odd, so at least one edge per pair is repeated
+	if cost < float64(g.Size()+100) {
+		t.Fatalf("ChinesePostman(): expected cost at least %d, got %v.", g.Size()+100, cost)
+	}
+	if path.GetLastVertex() != path.start || len(path.out) != int(cost) {
+		t.Fatalf("ChinesePostman(): expected a closed path of %v edges, got %d.", cost, len(path.out))
+	}
+	used := make([]bool, g.Size())
+	for _, o := range path.out {
+		used[o.Edge] = true
+	}
+	for e, ok := range used {
+		if !ok {
+			t.Fatalf("edge: %d was not traversed.", e)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package graph
+
+import "container/heap"
+
+// an entry of a priority queue of vertexes
+type pqItem struct {
+	vertex   int
+	priority float64
+}
+
+// a min-priority queue of vertexes implementing heap.Interface
+// a vertex can be pushed several times, callers are expected to skip stale entries
+type pqueue []pqItem
+
+func (q pqueue) Len() int           { return len(q) }
+func (q pqueue) Less(i, j int) bool { return q[i].priority < q[j].priority }
+func (q pqueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+
+func (q *pqueue) Push(x interface{}) {
+	*q = append(*q, x.(pqItem))
+}
+
+func (q *pqueue) Pop() interface{} {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
+
+// pushes the vertex with the given priority
+func (q *pqueue) push(vertex int, priority float64) {
+	heap.Push(q, pqItem{vertex, priority})
+}
+
+// removes and returns the entry with the lowest priority
+func (q *pqueue) pop() pqItem {
+	return heap.Pop(q).(pqItem)
+}
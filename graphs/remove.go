@@ -0,0 +1,120 @@
+package graph
+
+// removes the edge E[edgeIndex] from this graph
+// the ids of the remaining edges do not change
+// returns an error if the edgeIndex is out of bounds or was already removed
+func (g *Graph) RemoveEdge(edgeIndex int) error {
+	if err := g.testEdge(edgeIndex); err != nil {
+		return err
+	}
+	e := &g.edges[edgeIndex]
+	vi, vj := e.endpoints[0], e.endpoints[1]
+	g.vertexes[vi].out = withoutEdge(g.vertexes[vi].out, edgeIndex)
+	if g.directed {
+		g.vertexes[vj].in = withoutEdge(g.vertexes[vj].in, edgeIndex)
+	} else if vi != vj {
+		g.vertexes[vj].out = withoutEdge(g.vertexes[vj].out, edgeIndex)
+	}
+	e.removed = true
+	g.changed()
+	return nil
+}
+
+// removes the vertex V[vertexIndex] and every edge incident to it from this graph
+// the ids of the remaining vertexes and edges do not change
+// returns an error if the vertexIndex is out of bounds or was already removed
+func (g *Graph) RemoveVertex(vertexIndex int) error {
+	if err := g.testVertex(vertexIndex); err != nil {
+		return err
+	}
+	v := &g.vertexes[vertexIndex]
+	// marks the incident edges removed first, so that the adjacencies
+	// of every neighbor are filtered once whatever the number of shared edges
+	neighbors := make(map[int]bool)
+	for _, adjacencies := range [][]Adjacency{v.out, v.in} {
+		for _, o := range adjacencies {
+			g.edges[o.Edge].removed = true
+			if o.Endpoint != vertexIndex {
+				neighbors[o.Endpoint] = true
+			}
+		}
+	}
+	for u := range neighbors {
+		g.vertexes[u].out = g.withoutRemoved(g.vertexes[u].out)
+		if g.directed {
+			g.vertexes[u].in = g.withoutRemoved(g.vertexes[u].in)
+		}
+	}
+	v.out = make([]Adjacency, 0)
+	if g.directed {
+		v.in = make([]Adjacency, 0)
+	}
+	v.removed = true
+	g.changed()
+	return nil
+}
+
+// renumbers the vertexes and edges of this graph so that removed ids are released
+// and ids range over [0, VertexCount()) and [0, EdgeCount()) again
+// the relative order of the remaining ids is kept
+// paths created before compacting are no longer valid, see Path.Validate
+// returns the new id of every old vertex id and of every old edge id,
+// removed vertexes and edges are mapped to -1
+func (g *Graph) Compact() ([]int, []int) {
+	vertexIds := make([]int, len(g.vertexes))
+	edgeIds := make([]int, len(g.edges))
+	vertexes := make([]Vertex, 0, g.VertexCount())
+	edges := make([]Edge, 0, g.EdgeCount())
+	for i, v := range g.vertexes {
+		vertexIds[i] = -1
+		if !v.removed {
+			vertexIds[i] = len(vertexes)
+			v.id = len(vertexes)
+			v.out, v.in = make([]Adjacency, 0), nil
+			vertexes = append(vertexes, v)
+		}
+	}
+	for i, e := range g.edges {
+		edgeIds[i] = -1
+		if e.removed {
+			continue
+		}
+		edgeIds[i] = len(edges)
+		e.id = len(edges)
+		vi, vj := vertexIds[e.endpoints[0]], vertexIds[e.endpoints[1]]
+		e.endpoints = [2]int{vi, vj}
+		edges = append(edges, e)
+		vertexes[vi].out = append(vertexes[vi].out, Adjacency{vj, e.id})
+		if g.directed {
+			vertexes[vj].in = append(vertexes[vj].in, Adjacency{vi, e.id})
+		} else if vi != vj {
+			vertexes[vj].out = append(vertexes[vj].out, Adjacency{vi, e.id})
+		}
+	}
+	g.vertexes, g.edges = vertexes, edges
+	g.changed()
+	g.compactions++
+	return vertexIds, edgeIds
+}
+
+// returns a copy of adjacencies without the entries of removed edges
+func (g *Graph) withoutRemoved(adjacencies []Adjacency) []Adjacency {
+	result := make([]Adjacency, 0, len(adjacencies))
+	for _, o := range adjacencies {
+		if !g.edges[o.Edge].removed {
+			result = append(result, o)
+		}
+	}
+	return result
+}
+
+// returns a copy of adjacencies without the entries of edge
+func withoutEdge(adjacencies []Adjacency, edge int) []Adjacency {
+	result := make([]Adjacency, 0, len(adjacencies))
+	for _, o := range adjacencies {
+		if o.Edge != edge {
+			result = append(result, o)
+		}
+	}
+	return result
+}
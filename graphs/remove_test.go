@@ -0,0 +1,104 @@
+package graph
+
+import "testing"
+
+// TestRemove:
+// Verify removed vertexes and edges keep the other ids stable
+func TestRemove(t *testing.T) {
+	g := Graph{}
+	for i := 0; i < 4; i++ {
+		g.AddVertex(i)
+	}
+	g.AddEdge(0, 1, "E1")
+	g.AddEdge(1, 2, "E2")
+	g.AddEdge(2, 3, "E3")
+	g.AddEdge(3, 0, "E4")
+	if err := g.RemoveEdge(0); err != nil {
+		t.Fatalf(err.Error())
+	}
+	if err := g.RemoveEdge(0); err != (ErrRemoved{"edge", 0}) {
+		t.Fatalf("g.RemoveEdge(0): expected '%v' error got '%v'.", ErrRemoved{"edge", 0}, err)
+	}
+	if err := g.RemoveVertex(2); err != nil {
+		t.Fatalf(err.Error())
+	}
+	if g.Order() != 4 || g.Size() != 4 || g.VertexCount() != 3 || g.EdgeCount() != 1 {
+		t.Fatalf("expected ids for 4 vertexes and 4 edges with 3 and 1 left, got %d, %d, %d and %d.", g.Order(), g.Size(), g.VertexCount(), g.EdgeCount())
+	}
+	if _, err := g.GetVertex(2); err != (ErrRemoved{"vertex", 2}) {
+		t.Fatalf("g.GetVertex(2): expected '%v' error got '%v'.", ErrRemoved{"vertex", 2}, err)
+	}
+	if e, _ := g.GetEdge(3); e.Data != "E4" {
+		t.Fatalf("g.GetEdge(3): expected data E4, got %v.", e.Data)
+	}
+	v, _ := g.GetVertex(1)
+	if v.Degree() != 0 {
+		t.Fatalf("vertex 1: expected degree 0, got %d.", v.Degree())
+	}
+	if _, count := ConnectedComponents(g); count != 2 {
+		t.Fatalf("ConnectedComponents(): expected 2 components, got %d.", count)
+	}
+
+	vertexIds, edgeIds := g.Compact()
+	if g.Order() != 3 || g.Size() != 1 || vertexIds[2] != -1 || vertexIds[3] != 2 || edgeIds[3] != 0 {
+		t.Fatalf("g.Compact(): unexpected remapping %v and %v.", vertexIds, edgeIds)
+	}
+	e, _ := g.GetEdge(0)
+	if vi, vj := e.Endpoints(); e.Data != "E4" || vi != 2 || vj != 0 {
+		t.Fatalf("g.Compact(): expected edge E4 between 2 and 0, got %v between %d and %d.", e.Data, vi, vj)
+	}
+	if v, _ := g.GetVertex(2); v.Data != 3 || v.Degree() != 1 {
+		t.Fatalf("g.Compact(): expected vertex 2 to hold 3 with degree 1, got %v with degree %d.", v.Data, v.Degree())
+	}
+}
+
+// TestRemoveHub:
+// Verify removing a vertex with parallel edges, self-loops and edges in both directions
+func TestRemoveHub(t *testing.T) {
+	g := NewDigraph()
+	for i := 0; i < 3; i++ {
+		g.AddVertex(i)
+	}
+	g.AddEdge(0, 1, nil)
+	g.AddEdge(0, 1, nil)
+	g.AddEdge(1, 0, nil)
+	g.AddEdge(0, 0, nil)
+	g.AddEdge(2, 0, nil)
+	g.AddEdge(1, 2, nil)
+	if err := g.RemoveVertex(0); err != nil {
+		t.Fatalf(err.Error())
+	}
+	if g.EdgeCount() != 1 || !g.EdgeExists(5) {
+		t.Fatalf("g.RemoveVertex(0): expected only edge 5 left, got %d edges.", g.EdgeCount())
+	}
+	for v := 1; v < 3; v++ {
+		vertex, _ := g.GetVertex(v)
+		if vertex.Degree() != 1 {
+			t.Fatalf("vertex %d: expected degree 1, got %d: %v %v.", v, vertex.Degree(), vertex.out, vertex.in)
+		}
+	}
+}
+
+// TestRemoveThroughCopy:
+// Verify the counts of a graph agree with its tombstones when a copy removes elements
+func TestRemoveThroughCopy(t *testing.T) {
+	g := Graph{}
+	for i := 0; i < 3; i++ {
+		g.AddVertex(i)
+	}
+	g.AddEdge(0, 1, nil)
+	g.AddEdge(1, 2, nil)
+	h := g
+	h.RemoveEdge(0)
+	h.RemoveVertex(2)
+	for _, c := range []Graph{g, h} {
+		if c.EdgeExists(0) || c.EdgeCount() != 0 || c.VertexExists(2) || c.VertexCount() != 2 {
+			t.Fatalf("expected 2 vertexes and no edges, got %d and %d.", c.VertexCount(), c.EdgeCount())
+		}
+	}
+	independent := ToGraph(g)
+	g.RemoveVertex(0)
+	if independent.VertexCount() != 2 || !independent.VertexExists(0) {
+		t.Fatalf("ToGraph(): expected an independent copy, got %d vertexes.", independent.VertexCount())
+	}
+}
@@ -0,0 +1,217 @@
+package graph
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+type station struct {
+	Name string `json:"name"`
+	Zone int    `json:"zone"`
+}
+
+// verifies read has the same vertexes, edges, weights and Data as g
+func checkRoundTrip(t *testing.T, format string, g, read Graph) {
+	if read.IsDirected() != g.IsDirected() || read.Order() != g.Order() || read.Size() != g.Size() {
+		t.Fatalf("%s: expected %d vertex and %d edge ids, got %d and %d.", format, g.Order(), g.Size(), read.Order(), read.Size())
+	}
+	for i := range g.vertexes {
+		v, w := g.vertexes[i], read.vertexes[i]
+		if v.removed != w.removed || !v.removed && v.Data != w.Data {
+			t.Fatalf("%s: vertex: %d expected %v, got %v.", format, i, v.Data, w.Data)
+		}
+	}
+	for i := range g.edges {
+		e, f := g.edges[i], read.edges[i]
+		if e.removed != f.removed || !e.removed && (e.Data != f.Data || e.weight != f.weight || e.endpoints[0] != f.endpoints[0] || e.endpoints[1] != f.endpoints[1]) {
+			t.Fatalf("%s: edge: %d expected %v, got %v.", format, i, e, f)
+		}
+	}
+}
+
+// TestSerializationRoundTrip:
+// Verify ids and Data survive GraphML and JSON
+func TestSerializationRoundTrip(t *testing.T) {
+	g := NewDigraph()
+	g.AddVertex(station{"north", 1})
+	g.AddVertex(station{"gone", 0})
+	g.AddVertex(station{"south \"2\"", 2})
+	g.AddVertex(nil)
+	g.AddWeightedEdge(0, 2, 2.5, station{"express", 1})
+	g.AddEdge(0, 1, nil)
+	g.AddEdge(2, 3, nil)
+	g.AddEdge(3, 0, station{"loop, back", 3})
+	g.RemoveVertex(1)
+	codec := JSONCodec{New: func() interface{} { return &station{} }}
+	codecs := Codecs{Vertex: codec, Edge: codec}
+	formats := map[string]struct {
+		write func(io.Writer, Interface, Codecs) error
+		read  func(io.Reader, Codecs) (Graph, error)
+	}{
+		"GraphML": {WriteGraphML, ReadGraphML},
+		"JSON":    {WriteJSON, ReadJSON},
+	}
+	for name, format := range formats {
+		var b bytes.Buffer
+		if err := format.write(&b, g, codecs); err != nil {
+			t.Fatalf(err.Error())
+		}
+		read, err := format.read(&b, codecs)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		// the codec decodes pointers to stations
+		for i := range read.vertexes {
+			if s, ok := read.vertexes[i].Data.(*station); ok {
+				read.vertexes[i].Data = *s
+			}
+		}
+		for i := range read.edges {
+			if s, ok := read.edges[i].Data.(*station); ok {
+				read.edges[i].Data = *s
+			}
+		}
+		checkRoundTrip(t, name, g, read)
+	}
+}
+
+// TestSerializeInterface:
+// Verify every writer gives the same output for a graph and its frozen copy,
+// and JSON payloads are embedded as JSON values
+func TestSerializeInterface(t *testing.T) {
+	g := NewGraph()
+	g.AddVertex(station{"north", 1})
+	g.AddVertex(nil)
+	g.AddVertex(station{"south", 2})
+	g.AddWeightedEdge(0, 2, 2.5, station{"express", 1})
+	g.AddEdge(1, 2, nil)
+	g.AddEdge(1, 0, nil)
+	codec := JSONCodec{}
+	writers := map[string]func(io.Writer, Interface) error{
+		"JSON":      func(w io.Writer, g Interface) error { return WriteJSON(w, g, Codecs{codec, codec}) },
+		"GraphML":   func(w io.Writer, g Interface) error { return WriteGraphML(w, g, Codecs{codec, codec}) },
+		"edge list": func(w io.Writer, g Interface) error { return WriteEdgeList(w, g, codec) },
+		"matrix":    func(w io.Writer, g Interface) error { return WriteAdjacencyMatrix(w, g, codec) },
+		"DOT":       func(w io.Writer, g Interface) error { return WriteDOT(w, g, DOTOptions{}) },
+	}
+	for name, write := range writers {
+		var expected, frozen bytes.Buffer
+		if err := write(&expected, g); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if err := write(&frozen, g.Freeze()); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if expected.String() != frozen.String() {
+			t.Fatalf("%s: expected\n%s\ngot\n%s", name, expected.String(), frozen.String())
+		}
+		if name == "JSON" && !strings.Contains(expected.String(), `"data": {`) {
+			t.Fatalf("WriteJSON(): expected data embedded as an object, got\n%s", expected.String())
+		}
+	}
+}
+
+// TestEdgeList:
+// Verify edge lists round trip edge ids and accept plain lists
+func TestEdgeList(t *testing.T) {
+	g := Graph{}
+	for i := 0; i < 4; i++ {
+		g.AddVertex(nil)
+	}
+	g.AddWeightedEdge(0, 1, 3, "first\tedge")
+	g.AddEdge(1, 2, nil)
+	g.AddEdge(2, 0, "third")
+	g.RemoveEdge(1)
+	var b bytes.Buffer
+	if err := WriteEdgeList(&b, g, nil); err != nil {
+		t.Fatalf(err.Error())
+	}
+	read, err := ReadEdgeList(&b, nil)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	checkRoundTrip(t, "edge list", g, read)
+
+	read, err = ReadEdgeList(strings.NewReader("0 1\n1 2 4.5\n"), nil)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if read.Order() != 3 || read.Size() != 2 || read.edges[1].weight != 4.5 {
+		t.Fatalf("ReadEdgeList(): expected 3 vertexes and 2 edges, got %d and %d.", read.Order(), read.Size())
+	}
+
+	// a payload holding a line that looks like a header
+	multiline := Graph{}
+	multiline.AddVertex(nil)
+	multiline.AddVertex(nil)
+	multiline.AddEdge(0, 1, "line\n# order 5")
+	b.Reset()
+	WriteEdgeList(&b, multiline, nil)
+	if read, err = ReadEdgeList(&b, nil); err != nil {
+		t.Fatalf(err.Error())
+	}
+	checkRoundTrip(t, "edge list", multiline, read)
+}
+
+// TestSerializationLargeIds:
+// Verify ids far beyond the number of elements read are rejected instead of allocated
+func TestSerializationLargeIds(t *testing.T) {
+	inputs := map[string]func() (Graph, error){
+		"json vertex": func() (Graph, error) {
+			return ReadJSON(strings.NewReader(`{"vertices": [{"id": 2000000000}], "edges": []}`), Codecs{})
+		},
+		"json edge": func() (Graph, error) {
+			return ReadJSON(strings.NewReader(`{"vertices": [{"id": 0}], "edges": [{"id": 2000000000, "source": 0, "target": 0}]}`), Codecs{})
+		},
+		"graphml": func() (Graph, error) {
+			return ReadGraphML(strings.NewReader(`<graphml><graph edgedefault="undirected"><node id="n2000000000"/></graph></graphml>`), Codecs{})
+		},
+		"edge list order": func() (Graph, error) {
+			return ReadEdgeList(strings.NewReader("# order 2000000000\n0\t0\t1\t1\t\n"), nil)
+		},
+		"edge list vertex": func() (Graph, error) {
+			return ReadEdgeList(strings.NewReader("0 2000000000\n"), nil)
+		},
+	}
+	for name, read := range inputs {
+		if _, err := read(); err == nil {
+			t.Fatalf("%s: expected an error for a huge id.", name)
+		}
+	}
+	// a few removed ids are still restored
+	read, err := ReadJSON(strings.NewReader(`{"vertices": [{"id": 0}, {"id": 40}], "edges": []}`), Codecs{})
+	if err != nil || read.Order() != 41 || read.VertexCount() != 2 {
+		t.Fatalf("ReadJSON(): expected 41 vertex ids with 2 vertexes, got %d and %d (%v).", read.Order(), read.VertexCount(), err)
+	}
+}
+
+// TestAdjacencyMatrix:
+// Verify adjacency matrices round trip vertex Data and weights
+func TestAdjacencyMatrix(t *testing.T) {
+	g := Graph{}
+	for _, name := range []string{"a", "b", "c"} {
+		g.AddVertex(name)
+	}
+	g.AddWeightedEdge(0, 1, 2, nil)
+	g.AddWeightedEdge(1, 2, 3, nil)
+	g.AddWeightedEdge(2, 2, 1, nil)
+	var b bytes.Buffer
+	if err := WriteAdjacencyMatrix(&b, g, nil); err != nil {
+		t.Fatalf(err.Error())
+	}
+	expected := ",a,b,c\na,,2,\nb,2,,3\nc,,3,1\n"
+	if b.String() != expected {
+		t.Fatalf("WriteAdjacencyMatrix(): expected\n%s\ngot\n%s", expected, b.String())
+	}
+	read, err := ReadAdjacencyMatrix(&b, false, nil)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	checkRoundTrip(t, "adjacency matrix", g, read)
+	g.AddEdge(0, 1, nil)
+	if err := WriteAdjacencyMatrix(&b, g, nil); err == nil {
+		t.Fatalf("WriteAdjacencyMatrix(): expected an error for parallel edges")
+	}
+}
@@ -0,0 +1,282 @@
+package graph
+
+import (
+	"fmt"
+	"math"
+)
+
+type ErrNegativeWeight struct {
+	Edge   int
+	Weight float64
+}
+
+func (err ErrNegativeWeight) Error() string {
+	return fmt.Sprintf("edge: %d has negative weight: %v", err.Edge, err.Weight)
+}
+
+// returned when a cycle whose total weight is negative is found
+// shortest paths are not defined in that case
+// Cycle is a closed path through the offending cycle
+type ErrNegativeCycle struct {
+	Cycle Path
+}
+
+func (err ErrNegativeCycle) Error() string {
+	return fmt.Sprintf("negative cycle through vertex: %d", err.Cycle.start)
+}
+
+type ErrUnreachable struct {
+	From int
+	To   int
+}
+
+func (err ErrUnreachable) Error() string {
+	return fmt.Sprintf("vertex: %d is not reachable from vertex: %d", err.To, err.From)
+}
+
+// a shortest path tree rooted at a source vertex
+// as computed by Dijkstra or BellmanFord
+type ShortestPaths struct {
+	graph  Interface
+	source int
+	dist   []float64
+	// prev[v] is the last step of the shortest path to v:
+	// the vertex it comes from and the edge used. prev[v].Edge is -1 if there is none
+	prev []Adjacency
+}
+
+// returns the vertex all paths start from
+func (sp ShortestPaths) Source() int {
+	return sp.source
+}
+
+// returns the cost of the shortest path from the source to vertexIndex
+// +Inf is returned if vertexIndex is unreachable or out of bounds
+func (sp ShortestPaths) Distance(vertexIndex int) float64 {
+	if vertexIndex < 0 || vertexIndex >= len(sp.dist) {
+		return math.Inf(1)
+	}
+	return sp.dist[vertexIndex]
+}
+
+// returns true if there is a path from the source to vertexIndex
+func (sp ShortestPaths) Reachable(vertexIndex int) bool {
+	return !math.IsInf(sp.Distance(vertexIndex), 1)
+}
+
+// returns the shortest path from the source to vertexIndex
+// or an error if vertexIndex is out of bounds or unreachable
+func (sp ShortestPaths) PathTo(vertexIndex int) (Path, error) {
+	if err := testVertex(sp.graph, vertexIndex); err != nil {
+		return Path{}, err
+	}
+	if !sp.Reachable(vertexIndex) {
+		return Path{}, ErrUnreachable{sp.source, vertexIndex}
+	}
+	return buildPath(sp.graph, sp.source, vertexIndex, sp.prev)
+}
+
+// builds the path from start to end following the predecessor steps in prev backwards
+func buildPath(g Interface, start, end int, prev []Adjacency) (Path, error) {
+	steps := make([]Adjacency, 0)
+	for v := end; v != start; {
+		step := prev[v]
+		if step.Edge == -1 {
+			return Path{}, ErrUnreachable{start, end}
+		}
+		steps = append(steps, Adjacency{v, step.Edge})
+		v = step.Endpoint
+		if len(steps) > g.Size() {
+			return Path{}, fmt.Errorf("predecessor chain from vertex: %d does not reach vertex: %d", end, start)
+		}
+	}
+	path, err := NewPath(g, start)
+	if err != nil {
+		return path, err
+	}
+	return path.growSteps(reverseSteps(steps))
+}
+
+// initializes the distance and predecessor arrays of a single source search
+func newSearch(g Interface, source int) ([]float64, []Adjacency) {
+	dist := make([]float64, g.Order())
+	prev := make([]Adjacency, g.Order())
+	for i := range dist {
+		dist[i] = math.Inf(1)
+		prev[i] = Adjacency{-1, -1}
+	}
+	dist[source] = 0
+	return dist, prev
+}
+
+// computes the shortest paths from source to every other vertex
+// edge costs are given by w, or by the edge weights if w is nil
+// an ErrNegativeWeight is returned if a reachable edge has a negative cost
+func Dijkstra(g Interface, source int, w WeightFunc) (ShortestPaths, error) {
+	if err := testVertex(g, source); err != nil {
+		return ShortestPaths{}, err
+	}
+	costOf := edgeCosts(g, w)
+	dist, prev := newSearch(g, source)
+	done := make([]bool, g.Order())
+	queue := &pqueue{}
+	queue.push(source, 0)
+	for queue.Len() > 0 {
+		u := queue.pop().vertex
+		if done[u] {
+			continue
+		}
+		done[u] = true
+		for _, o := range adjacencies(g, u) {
+			cost := costOf(o.Edge)
+			if cost < 0 {
+				return ShortestPaths{}, ErrNegativeWeight{o.Edge, cost}
+			}
+			if d := dist[u] + cost; d < dist[o.Endpoint] {
+				dist[o.Endpoint] = d
+				prev[o.Endpoint] = Adjacency{u, o.Edge}
+				queue.push(o.Endpoint, d)
+			}
+		}
+	}
+	return ShortestPaths{g, source, dist, prev}, nil
+}
+
+// computes the shortest paths from source to every other vertex allowing negative costs
+// edge costs are given by w, or by the edge weights if w is nil
+// an ErrNegativeCycle is returned if a negative cycle is reachable from source
+// note that an undirected edge with negative cost is itself a negative cycle
+func BellmanFord(g Interface, source int, w WeightFunc) (ShortestPaths, error) {
+	if err := testVertex(g, source); err != nil {
+		return ShortestPaths{}, err
+	}
+	costOf := edgeCosts(g, w)
+	dist, prev := newSearch(g, source)
+	relax := func() int {
+		last := -1
+		for u := 0; u < g.Order(); u++ {
+			if math.IsInf(dist[u], 1) {
+				continue
+			}
+			for _, o := range adjacencies(g, u) {
+				if d := dist[u] + costOf(o.Edge); d < dist[o.Endpoint] {
+					dist[o.Endpoint] = d
+					prev[o.Endpoint] = Adjacency{u, o.Edge}
+					last = o.Endpoint
+				}
+			}
+		}
+		return last
+	}
+	for i := 1; i < g.Order(); i++ {
+		if relax() == -1 {
+			return ShortestPaths{g, source, dist, prev}, nil
+		}
+	}
+	if v := relax(); v != -1 {
+		cycle, err := negativeCycle(g, v, prev)
+		if err != nil {
+			return ShortestPaths{}, err
+		}
+		return ShortestPaths{}, ErrNegativeCycle{cycle}
+	}
+	return ShortestPaths{g, source, dist, prev}, nil
+}
+
+// extracts the cycle of the predecessor graph reached from v
+func negativeCycle(g Interface, v int, prev []Adjacency) (Path, error) {
+	// after |V| steps back v is guaranteed to lie on the cycle
+	for i := 0; i < g.Order(); i++ {
+		v = prev[v].Endpoint
+	}
+	steps := []Adjacency{}
+	for u := v; len(steps) == 0 || u != v; u = prev[u].Endpoint {
+		steps = append(steps, Adjacency{u, prev[u].Edge})
+	}
+	path, err := NewPath(g, v)
+	if err != nil {
+		return path, err
+	}
+	return path.growSteps(reverseSteps(steps))
+}
+
+// reverses steps in place and returns it
+func reverseSteps(steps []Adjacency) []Adjacency {
+	for i, j := 0, len(steps)-1; i < j; i, j = i+1, j-1 {
+		steps[i], steps[j] = steps[j], steps[i]
+	}
+	return steps
+}
+
+// shortest paths between every pair of vertexes as computed by FloydWarshall
+type AllShortestPaths struct {
+	graph Interface
+	dist  [][]float64
+	// prev[i][j] is the last step of the shortest path from i to j
+	prev [][]Adjacency
+}
+
+// returns the cost of the shortest path from vi to vj
+// +Inf is returned if vj is unreachable from vi or any index is out of bounds
+func (ap AllShortestPaths) Distance(vi, vj int) float64 {
+	if testVertex(ap.graph, vi, vj) != nil {
+		return math.Inf(1)
+	}
+	return ap.dist[vi][vj]
+}
+
+// returns the shortest path from vi to vj
+// or an error if any index is out of bounds or vj is unreachable from vi
+func (ap AllShortestPaths) PathBetween(vi, vj int) (Path, error) {
+	if err := testVertex(ap.graph, vi, vj); err != nil {
+		return Path{}, err
+	}
+	if math.IsInf(ap.dist[vi][vj], 1) {
+		return Path{}, ErrUnreachable{vi, vj}
+	}
+	return buildPath(ap.graph, vi, vj, ap.prev[vi])
+}
+
+// computes the shortest paths between every pair of vertexes
+// edge costs are given by w, or by the edge weights if w is nil
+// an ErrNegativeCycle is returned if the graph contains a negative cycle
+func FloydWarshall(g Interface, w WeightFunc) (AllShortestPaths, error) {
+	costOf := edgeCosts(g, w)
+	n := g.Order()
+	dist := make([][]float64, n)
+	prev := make([][]Adjacency, n)
+	for i := 0; i < n; i++ {
+		dist[i], prev[i] = newSearch(g, i)
+	}
+	for u := 0; u < g.Order(); u++ {
+		for _, o := range adjacencies(g, u) {
+			if cost := costOf(o.Edge); cost < dist[u][o.Endpoint] {
+				dist[u][o.Endpoint] = cost
+				prev[u][o.Endpoint] = Adjacency{u, o.Edge}
+			}
+		}
+	}
+	for k := 0; k < n; k++ {
+		for i := 0; i < n; i++ {
+			if math.IsInf(dist[i][k], 1) {
+				continue
+			}
+			for j := 0; j < n; j++ {
+				if d := dist[i][k] + dist[k][j]; d < dist[i][j] {
+					dist[i][j] = d
+					prev[i][j] = prev[k][j]
+				}
+			}
+		}
+	}
+	for i := 0; i < n; i++ {
+		// a negative cycle goes through i, so BellmanFord from i finds it
+		// and returns it in an ErrNegativeCycle
+		if dist[i][i] < 0 {
+			if _, err := BellmanFord(g, i, w); err != nil {
+				return AllShortestPaths{}, err
+			}
+		}
+	}
+	return AllShortestPaths{g, dist, prev}, nil
+}
@@ -0,0 +1,120 @@
+package graph
+
+import (
+	"math"
+	"testing"
+)
+
+// TestDijkstra:
+// Verify distances and paths computed by Dijkstra
+func TestDijkstra(t *testing.T) {
+	g := Graph{}
+	for i := 0; i < 4; i++ {
+		g.AddVertex(i)
+	}
+	g.AddWeightedEdge(0, 1, 1, nil)
+	g.AddWeightedEdge(1, 2, 1, nil)
+	g.AddWeightedEdge(0, 2, 5, nil)
+	sp, err := Dijkstra(g, 0, nil)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if sp.Distance(2) != 2 {
+		t.Fatalf("sp.Distance(2): expected value 2, got %v.", sp.Distance(2))
+	}
+	path, err := sp.PathTo(2)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if len(path.out) != 2 || path.out[0].Endpoint != 1 || path.GetLastVertex() != 2 {
+		t.Fatalf("sp.PathTo(2): expected path 0 1 2, got %v.", path.out)
+	}
+	if _, err := sp.PathTo(3); err != (ErrUnreachable{0, 3}) {
+		t.Fatalf("sp.PathTo(3): expected '%v' error got '%v'.", ErrUnreachable{0, 3}, err)
+	}
+	g.SetWeight(1, -1)
+	if _, err := Dijkstra(g, 0, nil); err == nil {
+		t.Fatalf("Dijkstra(): expected a negative weight error")
+	}
+}
+
+// TestBellmanFord:
+// Verify negative weights are supported and negative cycles reported
+func TestBellmanFord(t *testing.T) {
+	g := NewDigraph()
+	for i := 0; i < 4; i++ {
+		g.AddVertex(i)
+	}
+	g.AddWeightedEdge(0, 1, 1, nil)
+	g.AddWeightedEdge(1, 2, 1, nil)
+	g.AddWeightedEdge(0, 2, 5, nil)
+	g.SetWeight(2, -3)
+	sp, err := BellmanFord(g, 0, nil)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if sp.Distance(2) != -3 || !math.IsInf(sp.Distance(3), 1) {
+		t.Fatalf("sp.Distance(): expected values -3 and +Inf, got %v and %v.", sp.Distance(2), sp.Distance(3))
+	}
+	g.AddWeightedEdge(2, 0, 1, nil)
+	_, err = BellmanFord(g, 0, nil)
+	cycleErr, ok := err.(ErrNegativeCycle)
+	if !ok {
+		t.Fatalf("BellmanFord(): expected a negative cycle error, got '%v'.", err)
+	}
+	if w, _ := cycleErr.Cycle.Weight(); w != -2 || cycleErr.Cycle.GetLastVertex() != cycleErr.Cycle.start {
+		t.Fatalf("negative cycle: expected a closed path of weight -2, got %v of weight %v.", cycleErr.Cycle.out, w)
+	}
+}
+
+// TestFloydWarshall:
+// Verify all pairs shortest paths agree with Dijkstra
+func TestFloydWarshall(t *testing.T) {
+	g := Graph{}
+	for i := 0; i < 4; i++ {
+		g.AddVertex(i)
+	}
+	g.AddWeightedEdge(0, 1, 1, nil)
+	g.AddWeightedEdge(1, 2, 1, nil)
+	g.AddWeightedEdge(0, 2, 5, nil)
+	ap, err := FloydWarshall(g, nil)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	for i := 0; i < g.Order(); i++ {
+		sp, _ := Dijkstra(g, i, nil)
+		for j := 0; j < g.Order(); j++ {
+			if ap.Distance(i, j) != sp.Distance(j) {
+				t.Fatalf("ap.Distance(%d, %d): expected value %v, got %v.", i, j, sp.Distance(j), ap.Distance(i, j))
+			}
+		}
+	}
+	path, err := ap.PathBetween(2, 0)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if w, _ := path.Weight(); w != 2 || path.GetLastVertex() != 0 {
+		t.Fatalf("ap.PathBetween(2, 0): expected a path of weight 2, got %v.", path.out)
+	}
+}
+
+// TestFloydWarshallNegativeCycle:
+// Verify the negative cycle found by FloydWarshall is returned
+func TestFloydWarshallNegativeCycle(t *testing.T) {
+	g := NewDigraph()
+	for i := 0; i < 4; i++ {
+		g.AddVertex(i)
+	}
+	g.AddWeightedEdge(0, 1, 1, nil)
+	g.AddWeightedEdge(1, 2, -3, nil)
+	g.AddWeightedEdge(2, 1, 1, nil)
+	g.AddWeightedEdge(2, 3, 4, nil)
+	_, err := FloydWarshall(g, nil)
+	cycleErr, ok := err.(ErrNegativeCycle)
+	if !ok {
+		t.Fatalf("FloydWarshall(): expected ErrNegativeCycle, got %v.", err)
+	}
+	if w, _ := cycleErr.Cycle.Weight(); w != -2 || !cycleErr.Cycle.IsClosed() || cycleErr.Cycle.Length() != 2 {
+		t.Fatalf("FloydWarshall(): expected the cycle 1 2 1 of weight -2, got %v.", cycleErr.Cycle.Vertices())
+	}
+}
@@ -0,0 +1,154 @@
+package graph
+
+import "sync"
+
+// a graph that can be shared by goroutines
+// writers change the graph under a lock while readers work on snapshots:
+// immutable copies of the graph that later writes never change
+// a snapshot is made by the first Snapshot call after a write
+// and shared by every reader until the next write
+type SyncGraph struct {
+	mu sync.RWMutex
+	g  Graph
+	// the last snapshot taken, it misses the writes made since then
+	snapshot *CSRGraph
+	// the edges whose weight was set since the snapshot was taken
+	reweighted []int
+	// true if the graph changed in a way the snapshot cannot be extended with
+	rebuild bool
+}
+
+// returns a graph that can be shared by goroutines, holding a copy of g
+// use NewSyncGraph(NewGraph()) or NewSyncGraph(NewDigraph()) to start from an empty graph
+func NewSyncGraph(g Graph) *SyncGraph {
+	return &SyncGraph{g: ToGraph(g)}
+}
+
+// returns an immutable copy of the graph as it is now
+// the copy can be read by any number of goroutines while writers change this graph.
+// it is made again only when the graph changed since the last call, sharing the arrays
+// of the last copy that did not change: after SetWeight only the weights are copied,
+// and vertexes appended by AddVertex extend the arrays of the last copy in place.
+// adding or removing edges, removing vertexes or calling Update rebuilds the whole copy
+// in O(V+E) time, so writes of that kind are best grouped in a single Update
+// rather than alternated with snapshots
+func (s *SyncGraph) Snapshot() CSRGraph {
+	s.mu.RLock()
+	snapshot := s.current()
+	s.mu.RUnlock()
+	if snapshot != nil {
+		return *snapshot
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.current() == nil {
+		s.refresh()
+	}
+	return *s.snapshot
+}
+
+// returns the last snapshot if it holds the graph as it is now, or nil
+func (s *SyncGraph) current() *CSRGraph {
+	if s.snapshot == nil || s.rebuild || len(s.reweighted) > 0 || s.snapshot.Order() != s.g.Order() {
+		return nil
+	}
+	return s.snapshot
+}
+
+// brings the snapshot up to date with the graph, see Snapshot
+func (s *SyncGraph) refresh() {
+	if s.snapshot == nil || s.rebuild {
+		c := s.g.Freeze()
+		s.snapshot, s.rebuild, s.reweighted = &c, false, s.reweighted[:0]
+		return
+	}
+	c := *s.snapshot.csr
+	if len(s.reweighted) > 0 {
+		c.weights = append([]float64(nil), c.weights...)
+		for _, e := range s.reweighted {
+			c.weights[e] = s.g.edges[e].weight
+		}
+	}
+	for v := len(c.offsets) - 1; v < s.g.Order(); v++ {
+		c.appendVertex(s.g.vertexes[v].Data)
+	}
+	s.snapshot, s.reweighted = &CSRGraph{&c}, s.reweighted[:0]
+}
+
+// calls update with the graph locked for writing, so that several changes
+// are seen at once by the snapshots taken afterwards
+// update must not keep g, nor pointers returned by its methods, after returning
+func (s *SyncGraph) Update(update func(g *Graph) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rebuild = true
+	return update(&s.g)
+}
+
+// returns true if the edges of this graph are directed
+func (s *SyncGraph) IsDirected() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.g.directed
+}
+
+// returns the number of vertex ids allocated in this graph, see Graph.Order
+func (s *SyncGraph) Order() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.g.Order()
+}
+
+// returns the number of edge ids allocated in this graph, see Graph.Size
+func (s *SyncGraph) Size() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.g.Size()
+}
+
+// appends a vertex holding data to this graph, see Graph.AddVertex
+func (s *SyncGraph) AddVertex(data interface{}) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.AddVertex(data)
+}
+
+// appends an edge of weight 1 joining V[vi] and V[vj], see Graph.AddEdge
+func (s *SyncGraph) AddEdge(vi, vj int, data interface{}) (int, error) {
+	return s.AddWeightedEdge(vi, vj, 1, data)
+}
+
+// same as AddEdge but sets the weight of the resulting edge
+func (s *SyncGraph) AddWeightedEdge(vi, vj int, weight float64, data interface{}) (int, error) {
+	var id int
+	err := s.Update(func(g *Graph) (err error) {
+		id, err = g.AddWeightedEdge(vi, vj, weight, data)
+		return err
+	})
+	return id, err
+}
+
+// sets the weight of the edge E[edgeIndex], see Graph.SetWeight
+func (s *SyncGraph) SetWeight(edgeIndex int, weight float64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.g.SetWeight(edgeIndex, weight); err != nil {
+		return err
+	}
+	s.reweighted = append(s.reweighted, edgeIndex)
+	return nil
+}
+
+// removes the edge E[edgeIndex] from this graph, see Graph.RemoveEdge
+func (s *SyncGraph) RemoveEdge(edgeIndex int) error {
+	return s.Update(func(g *Graph) error {
+		return g.RemoveEdge(edgeIndex)
+	})
+}
+
+// removes the vertex V[vertexIndex] and its edges from this graph, see Graph.RemoveVertex
+func (s *SyncGraph) RemoveVertex(vertexIndex int) error {
+	return s.Update(func(g *Graph) error {
+		return g.RemoveVertex(vertexIndex)
+	})
+}
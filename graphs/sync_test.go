@@ -0,0 +1,149 @@
+package graph
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// TestSyncGraphSnapshot:
+// Verify snapshots do not change when the graph is written
+func TestSyncGraphSnapshot(t *testing.T) {
+	s := NewSyncGraph(NewDigraph())
+	for i := 0; i < 3; i++ {
+		s.AddVertex(i)
+	}
+	s.AddEdge(0, 1, nil)
+	before := s.Snapshot()
+	if again := s.Snapshot(); &again.out[0] != &before.out[0] {
+		t.Fatalf("Snapshot(): expected the snapshot to be reused without writes.")
+	}
+	s.AddEdge(1, 2, nil)
+	s.RemoveEdge(0)
+	if before.EdgeCount() != 1 || !before.EdgeExists(0) {
+		t.Fatalf("Snapshot(): expected the old snapshot to keep edge 0, got %d edges.", before.EdgeCount())
+	}
+	after := s.Snapshot()
+	if after.EdgeCount() != 1 || after.EdgeExists(0) || !after.EdgeExists(1) {
+		t.Fatalf("Snapshot(): expected only edge 1, got %d edges.", after.EdgeCount())
+	}
+	if _, err := s.AddEdge(0, 3, nil); err == nil {
+		t.Fatalf("AddEdge(): expected an error for vertex 3.")
+	}
+	err := s.Update(func(g *Graph) error {
+		g.RemoveVertex(0)
+		g.Compact()
+		return nil
+	})
+	if err != nil || s.Order() != 2 || s.Size() != 1 {
+		t.Fatalf("Update(): expected 2 vertexes and 1 edge, got %d and %d: %v.", s.Order(), s.Size(), err)
+	}
+}
+
+// TestSyncGraphConcurrent:
+// Verify readers get consistent snapshots while writers append, run with -race
+func TestSyncGraphConcurrent(t *testing.T) {
+	s := NewSyncGraph(NewGraph())
+	s.AddVertex(nil)
+	var wg sync.WaitGroup
+	for w := 0; w < 2; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				// every new vertex is joined to vertex 0, so snapshots stay connected
+				s.Update(func(g *Graph) error {
+					v := g.AddVertex(i)
+					_, err := g.AddEdge(0, v, nil)
+					return err
+				})
+			}
+		}()
+	}
+	errs := make(chan error, 4)
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				c := s.Snapshot()
+				if c.EdgeCount() != c.VertexCount()-1 {
+					errs <- fmt.Errorf("%d vertexes and %d edges", c.VertexCount(), c.EdgeCount())
+					return
+				}
+				if _, count := ConnectedComponents(c); count != 1 {
+					errs <- fmt.Errorf("%d components", count)
+					return
+				}
+				if v, _ := c.GetVertex(0); v.Degree() != c.EdgeCount() {
+					errs <- fmt.Errorf("vertex 0 has degree %d", v.Degree())
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatalf("Snapshot(): inconsistent snapshot: %v.", err)
+	}
+	if c := s.Snapshot(); c.VertexCount() != 401 || c.EdgeCount() != 400 {
+		t.Fatalf("Snapshot(): expected 401 vertexes and 400 edges, got %d and %d.", c.VertexCount(), c.EdgeCount())
+	}
+}
+
+// TestSyncGraphSharedSnapshots:
+// Verify snapshots share the arrays that weights and new vertexes do not change
+func TestSyncGraphSharedSnapshots(t *testing.T) {
+	s := NewSyncGraph(NewDigraph())
+	for i := 0; i < 3; i++ {
+		s.AddVertex(nil)
+	}
+	s.AddEdge(0, 1, nil)
+	s.AddEdge(1, 2, nil)
+	first := s.Snapshot()
+	s.SetWeight(1, 5)
+	reweighted := s.Snapshot()
+	if &reweighted.out[0] != &first.out[0] || first.weights[1] != 1 || reweighted.weights[1] != 5 {
+		t.Fatalf("Snapshot(): expected new weights over the same adjacencies, got %v and %v.", first.weights, reweighted.weights)
+	}
+	s.AddVertex("x")
+	s.AddVertex(nil)
+	extended := s.Snapshot()
+	if &extended.out[0] != &first.out[0] || reweighted.Order() != 3 || extended.Order() != 5 {
+		t.Fatalf("Snapshot(): expected 3 and 5 vertexes over the same adjacencies, got %d and %d.", reweighted.Order(), extended.Order())
+	}
+	if !reflect.DeepEqual(*extended.csr, *s.g.Freeze().csr) {
+		t.Fatalf("Snapshot(): expected the same arrays as Freeze, got %+v.", *extended.csr)
+	}
+	s.AddEdge(4, 3, nil)
+	if rebuilt := s.Snapshot(); &rebuilt.out[0] == &first.out[0] || rebuilt.EdgeCount() != 3 || extended.EdgeCount() != 2 {
+		t.Fatalf("Snapshot(): expected a new snapshot with 3 edges, got %d.", rebuilt.EdgeCount())
+	}
+}
+
+// BenchmarkSyncGraphSnapshot:
+// Measures a snapshot taken after every write, for each kind of write
+func BenchmarkSyncGraphSnapshot(b *testing.B) {
+	writes := map[string]func(s *SyncGraph, i int){
+		"SetWeight": func(s *SyncGraph, i int) { s.SetWeight(i%s.Size(), float64(i)) },
+		"AddVertex": func(s *SyncGraph, i int) { s.AddVertex(i) },
+		"AddEdge":   func(s *SyncGraph, i int) { s.AddEdge(i%s.Order(), (i+1)%s.Order(), nil) },
+	}
+	for name, write := range writes {
+		b.Run(name, func(b *testing.B) {
+			g, err := RandomRegular(10000, 10, 1)
+			if err != nil {
+				b.Fatalf(err.Error())
+			}
+			s := NewSyncGraph(g)
+			s.Snapshot()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				write(s, i)
+				s.Snapshot()
+			}
+		})
+	}
+}
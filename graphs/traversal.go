@@ -0,0 +1,234 @@
+package graph
+
+import (
+	"fmt"
+
+	"github.com/extradiable/golang/ds/stacks"
+)
+
+// returned by a Visitor hook, or by the callback of Path.WalkPath, to end a traversal early
+// BFS, DFS and WalkPath return nil when a traversal is stopped this way
+var ErrStop = fmt.Errorf("stop traversal")
+
+// hooks invoked by BFS and DFS while traversing a graph
+// any hook can be left nil. if a hook returns an error the traversal ends
+// and the error is returned, unless it is ErrStop
+type Visitor struct {
+	// called when vertex is reached for the first time
+	// depth is the number of tree edges from the start vertex
+	DiscoverVertex func(vertex, depth int) error
+	// called once the edges leaving vertex were examined
+	FinishVertex func(vertex int) error
+	// called for edges leading to an undiscovered vertex
+	TreeEdge func(from, to, edge int) error
+	// DFS only: called for edges leading to a vertex whose edges are still being examined
+	BackEdge func(from, to, edge int) error
+	// DFS on directed graphs only: called for edges leading to a finished descendant
+	ForwardEdge func(from, to, edge int) error
+	// called for edges leading to a finished vertex that is not a descendant
+	// BFS calls it for every edge that is not a tree edge
+	CrossEdge func(from, to, edge int) error
+}
+
+type color int
+
+const (
+	white color = iota // undiscovered
+	gray               // discovered but not finished
+	black              // finished
+)
+
+// calls fn if it is not nil
+func visitVertex(fn func(int) error, vertex int) error {
+	if fn == nil {
+		return nil
+	}
+	return fn(vertex)
+}
+
+// calls fn if it is not nil
+func visitEdge(fn func(int, int, int) error, from, to, edge int) error {
+	if fn == nil {
+		return nil
+	}
+	return fn(from, to, edge)
+}
+
+// translates ErrStop into a regular end of the traversal
+func stopped(err error) error {
+	if err == ErrStop {
+		return nil
+	}
+	return err
+}
+
+// traverses the graph breadth first from start
+// vertexes at depth maxDepth are discovered but their edges are not examined
+// a negative maxDepth means no depth limit
+func BFS(g Interface, start, maxDepth int, visitor Visitor) error {
+	if err := testVertex(g, start); err != nil {
+		return err
+	}
+	colors := make([]color, g.Order())
+	depth := make([]int, g.Order())
+	examined := make([]bool, g.Size())
+	discover := func(vertex, d int) error {
+		colors[vertex] = gray
+		depth[vertex] = d
+		if visitor.DiscoverVertex == nil {
+			return nil
+		}
+		return visitor.DiscoverVertex(vertex, d)
+	}
+	if err := discover(start, 0); err != nil {
+		return stopped(err)
+	}
+	queue := []int{start}
+	for len(queue) > 0 {
+		u := queue[0]
+		queue = queue[1:]
+		if maxDepth < 0 || depth[u] < maxDepth {
+			for _, o := range adjacencies(g, u) {
+				// undirected edges are examined from one endpoint only
+				if examined[o.Edge] && !g.IsDirected() {
+					continue
+				}
+				examined[o.Edge] = true
+				var err error
+				if colors[o.Endpoint] == white {
+					if err = visitEdge(visitor.TreeEdge, u, o.Endpoint, o.Edge); err == nil {
+						err = discover(o.Endpoint, depth[u]+1)
+					}
+					queue = append(queue, o.Endpoint)
+				} else {
+					err = visitEdge(visitor.CrossEdge, u, o.Endpoint, o.Edge)
+				}
+				if err != nil {
+					return stopped(err)
+				}
+			}
+		}
+		colors[u] = black
+		if err := visitVertex(visitor.FinishVertex, u); err != nil {
+			return stopped(err)
+		}
+	}
+	return nil
+}
+
+// a vertex whose edges are being examined by DFS
+type dfsFrame struct {
+	vertex int
+	depth  int
+	// index of the next adjacency to examine
+	next int
+}
+
+// the state of a depth first search, shared by the trees of a DFS forest
+type dfsState struct {
+	g         Interface
+	visitor   Visitor
+	maxDepth  int
+	colors    []color
+	discovery []int
+	examined  []bool
+	time      int
+	stack     *stacks.DStack
+}
+
+func newDFSState(g Interface, maxDepth int, visitor Visitor) *dfsState {
+	return &dfsState{
+		g:         g,
+		visitor:   visitor,
+		maxDepth:  maxDepth,
+		colors:    make([]color, g.Order()),
+		discovery: make([]int, g.Order()),
+		examined:  make([]bool, g.Size()),
+		stack:     stacks.CreateDStack(),
+	}
+}
+
+// traverses the graph depth first from start
+// the frontier is kept in an explicit stack, so deep graphs do not exhaust the call stack
+// vertexes at depth maxDepth are discovered but their edges are not examined
+// a negative maxDepth means no depth limit
+func DFS(g Interface, start, maxDepth int, visitor Visitor) error {
+	if err := testVertex(g, start); err != nil {
+		return err
+	}
+	return stopped(newDFSState(g, maxDepth, visitor).search(start))
+}
+
+// traverses the whole graph depth first
+// a new search is started from every vertex left undiscovered, in order of their ids
+func DFSAll(g Interface, visitor Visitor) error {
+	s := newDFSState(g, -1, visitor)
+	for v := 0; v < g.Order(); v++ {
+		if s.colors[v] != white || !vertexExists(g, v) {
+			continue
+		}
+		if err := s.search(v); err != nil {
+			return stopped(err)
+		}
+	}
+	return nil
+}
+
+// marks vertex as discovered and pushes it into the frontier
+func (s *dfsState) discover(vertex, depth int) error {
+	s.colors[vertex] = gray
+	s.discovery[vertex] = s.time
+	s.time++
+	s.stack.Push(&dfsFrame{vertex: vertex, depth: depth})
+	if s.visitor.DiscoverVertex == nil {
+		return nil
+	}
+	return s.visitor.DiscoverVertex(vertex, depth)
+}
+
+// builds the depth first tree rooted at start
+func (s *dfsState) search(start int) error {
+	visitor := s.visitor
+	if err := s.discover(start, 0); err != nil {
+		return err
+	}
+	for !s.stack.Empty() {
+		top, err := s.stack.Pop()
+		if err != nil {
+			return err
+		}
+		f := top.(*dfsFrame)
+		adjacencies := adjacencies(s.g, f.vertex)
+		if f.next == len(adjacencies) || (s.maxDepth >= 0 && f.depth >= s.maxDepth) {
+			s.colors[f.vertex] = black
+			if err := visitVertex(visitor.FinishVertex, f.vertex); err != nil {
+				return err
+			}
+			continue
+		}
+		o := adjacencies[f.next]
+		f.next++
+		s.stack.Push(f)
+		// undirected edges are examined from one endpoint only
+		if s.examined[o.Edge] && !s.g.IsDirected() {
+			continue
+		}
+		s.examined[o.Edge] = true
+		switch {
+		case s.colors[o.Endpoint] == white:
+			if err = visitEdge(visitor.TreeEdge, f.vertex, o.Endpoint, o.Edge); err == nil {
+				err = s.discover(o.Endpoint, f.depth+1)
+			}
+		case s.colors[o.Endpoint] == gray:
+			err = visitEdge(visitor.BackEdge, f.vertex, o.Endpoint, o.Edge)
+		case s.discovery[o.Endpoint] > s.discovery[f.vertex]:
+			err = visitEdge(visitor.ForwardEdge, f.vertex, o.Endpoint, o.Edge)
+		default:
+			err = visitEdge(visitor.CrossEdge, f.vertex, o.Endpoint, o.Edge)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package graph
+
+import "testing"
+
+// counts the vertexes and edges reported to each hook
+type visitCounter struct {
+	discovered, finished       []int
+	tree, back, forward, cross int
+}
+
+func (c *visitCounter) visitor() Visitor {
+	return Visitor{
+		DiscoverVertex: func(v, depth int) error { c.discovered = append(c.discovered, v); return nil },
+		FinishVertex:   func(v int) error { c.finished = append(c.finished, v); return nil },
+		TreeEdge:       func(from, to, e int) error { c.tree++; return nil },
+		BackEdge:       func(from, to, e int) error { c.back++; return nil },
+		ForwardEdge:    func(from, to, e int) error { c.forward++; return nil },
+		CrossEdge:      func(from, to, e int) error { c.cross++; return nil },
+	}
+}
+
+// TestBFS:
+// Verify vertexes are discovered in breadth first order
+func TestBFS(t *testing.T) {
+	g := Graph{}
+	for i := 0; i < 5; i++ {
+		g.AddVertex(i)
+	}
+	g.AddEdge(0, 1, nil)
+	g.AddEdge(1, 2, nil)
+	g.AddEdge(2, 0, nil)
+	g.AddEdge(0, 3, nil)
+	g.AddEdge(3, 4, nil)
+	c := visitCounter{}
+	if err := BFS(g, 0, -1, c.visitor()); err != nil {
+		t.Fatalf(err.Error())
+	}
+	expected := []int{0, 1, 2, 3, 4}
+	for i, v := range expected {
+		if c.discovered[i] != v {
+			t.Fatalf("BFS(): expected discovery order %v, got %v.", expected, c.discovered)
+		}
+	}
+	if c.tree != 4 || c.cross != 1 || len(c.finished) != 5 {
+		t.Fatalf("BFS(): expected 4 tree edges and 1 cross edge, got %d and %d.", c.tree, c.cross)
+	}
+	c = visitCounter{}
+	BFS(g, 0, 1, c.visitor())
+	if len(c.discovered) != 4 {
+		t.Fatalf("BFS(): expected 4 vertexes within depth 1, got %v.", c.discovered)
+	}
+}
+
+// TestDFS:
+// Verify edges are classified and early termination
+func TestDFS(t *testing.T) {
+	g := Graph{}
+	for i := 0; i < 5; i++ {
+		g.AddVertex(i)
+	}
+	g.AddEdge(0, 1, nil)
+	g.AddEdge(1, 2, nil)
+	g.AddEdge(2, 0, nil)
+	g.AddEdge(0, 3, nil)
+	g.AddEdge(3, 4, nil)
+	c := visitCounter{}
+	if err := DFS(g, 0, -1, c.visitor()); err != nil {
+		t.Fatalf(err.Error())
+	}
+	if c.tree != 4 || c.back != 1 || c.forward != 0 || c.cross != 0 {
+		t.Fatalf("DFS(): expected 4 tree edges and 1 back edge, got %d and %d.", c.tree, c.back)
+	}
+	if c.finished[len(c.finished)-1] != 0 {
+		t.Fatalf("DFS(): expected the start vertex to finish last, got %v.", c.finished)
+	}
+
+	d := NewDigraph()
+	for i := 0; i < 5; i++ {
+		d.AddVertex(i)
+	}
+	d.AddEdge(0, 1, nil)
+	d.AddEdge(1, 2, nil)
+	d.AddEdge(2, 0, nil)
+	d.AddEdge(0, 3, nil)
+	d.AddEdge(3, 4, nil)
+	d.AddEdge(0, 2, nil)
+	d.AddEdge(4, 1, nil)
+	c = visitCounter{}
+	DFS(d, 0, -1, c.visitor())
+	if c.tree != 4 || c.back != 1 || c.forward != 1 || c.cross != 1 {
+		t.Fatalf("DFS(): expected 4 tree, 1 back, 1 forward and 1 cross edges, got %d, %d, %d and %d.", c.tree, c.back, c.forward, c.cross)
+	}
+
+	visited := 0
+	err := DFS(g, 0, -1, Visitor{DiscoverVertex: func(v, depth int) error {
+		visited++
+		if v == 2 {
+			return ErrStop
+		}
+		return nil
+	}})
+	if err != nil || visited != 3 {
+		t.Fatalf("DFS(): expected to stop after 3 vertexes, got %d (%v).", visited, err)
+	}
+}
@@ -0,0 +1,145 @@
+package graph
+
+import "fmt"
+
+var ErrOtherGraph = fmt.Errorf("path belongs to another graph")
+
+// a graph whose vertexes hold values of type V and whose edges hold values of type E
+// it is backed by an untyped Graph, see Untyped, so the algorithms of this package
+// can be run on it and their results read back with strong types
+type TypedGraph[V, E any] struct {
+	g Graph
+}
+
+// a vertex of a TypedGraph
+type TypedVertex[V any] struct {
+	*Vertex
+	// shadows Vertex.Data with its typed value
+	Data V
+}
+
+// an edge of a TypedGraph
+type TypedEdge[E any] struct {
+	*Edge
+	// shadows Edge.Data with its typed value
+	Data E
+}
+
+// returns an empty undirected typed graph
+func NewTypedGraph[V, E any]() *TypedGraph[V, E] {
+	return &TypedGraph[V, E]{g: NewGraph()}
+}
+
+// returns an empty directed typed graph
+func NewTypedDigraph[V, E any]() *TypedGraph[V, E] {
+	return &TypedGraph[V, E]{g: NewDigraph()}
+}
+
+// returns the untyped graph backing t
+// vertexes and edges added to it must hold values of type V and E respectively
+func (t *TypedGraph[V, E]) Untyped() *Graph {
+	return &t.g
+}
+
+// returns the number of vertex ids allocated in this graph, see Graph.Order
+func (t *TypedGraph[V, E]) Order() int {
+	return t.g.Order()
+}
+
+// returns the number of edge ids allocated in this graph, see Graph.Size
+func (t *TypedGraph[V, E]) Size() int {
+	return t.g.Size()
+}
+
+// appends a vertex holding v to this graph, see Graph.AddVertex
+func (t *TypedGraph[V, E]) AddVertex(v V) int {
+	return t.g.AddVertex(v)
+}
+
+// appends an edge holding e to this graph, see Graph.AddEdge
+func (t *TypedGraph[V, E]) AddEdge(vi, vj int, e E) (int, error) {
+	return t.g.AddEdge(vi, vj, e)
+}
+
+// appends an edge holding e with the given weight to this graph, see Graph.AddWeightedEdge
+func (t *TypedGraph[V, E]) AddWeightedEdge(vi, vj int, weight float64, e E) (int, error) {
+	return t.g.AddWeightedEdge(vi, vj, weight, e)
+}
+
+// returns the vertex V[vertexIndex]
+// or error if the vertexIndex is out of bounds or the vertex does not hold a V
+func (t *TypedGraph[V, E]) GetVertex(vertexIndex int) (*TypedVertex[V], error) {
+	v, err := t.g.GetVertex(vertexIndex)
+	if err != nil {
+		return nil, err
+	}
+	return typedVertex[V](v)
+}
+
+// returns the edge E[edgeIndex]
+// or error if the edgeIndex is out of bounds or the edge does not hold an E
+func (t *TypedGraph[V, E]) GetEdge(edgeIndex int) (*TypedEdge[E], error) {
+	e, err := t.g.GetEdge(edgeIndex)
+	if err != nil {
+		return nil, err
+	}
+	return typedEdge[E](e)
+}
+
+// wraps v, or returns an error if it does not hold a V
+func typedVertex[V any](v *Vertex) (*TypedVertex[V], error) {
+	data, ok := v.Data.(V)
+	if !ok && v.Data != nil {
+		return nil, fmt.Errorf("vertex: %d holds %T, not %T", v.id, v.Data, data)
+	}
+	return &TypedVertex[V]{v, data}, nil
+}
+
+// wraps e, or returns an error if it does not hold an E
+func typedEdge[E any](e *Edge) (*TypedEdge[E], error) {
+	data, ok := e.Data.(E)
+	if !ok && e.Data != nil {
+		return nil, fmt.Errorf("edge: %d holds %T, not %T", e.id, e.Data, data)
+	}
+	return &TypedEdge[E]{e, data}, nil
+}
+
+// returns a path starting at V[start], see Graph.NewPath
+func (t *TypedGraph[V, E]) NewPath(start int) (Path, error) {
+	return t.g.NewPath(start)
+}
+
+// calls fn for every step of the path p, as Path.TraversePath does,
+// with typed vertexes and edges
+// returns ErrOtherGraph if p is not a path over this graph
+func (t *TypedGraph[V, E]) TraversePath(p Path, fn func(vi, vj *TypedVertex[V], e *TypedEdge[E])) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	if id := p.identity(); id == nil || id != t.g.identity {
+		return ErrOtherGraph
+	}
+	steps := p.Steps()
+	for steps.Next() {
+		vi, vj, e := steps.Step()
+		tvi, err := typedVertex[V](vi)
+		if err != nil {
+			return err
+		}
+		if vj == nil {
+			// a path without edges has a single step at its start
+			fn(tvi, nil, nil)
+			continue
+		}
+		tvj, err := typedVertex[V](vj)
+		if err != nil {
+			return err
+		}
+		te, err := typedEdge[E](e)
+		if err != nil {
+			return err
+		}
+		fn(tvi, tvj, te)
+	}
+	return steps.Err()
+}
@@ -0,0 +1,58 @@
+package graph
+
+import "testing"
+
+type city struct {
+	name string
+}
+
+type road struct {
+	km int
+}
+
+// TestTypedGraph:
+// Verify typed payloads are returned without assertions
+func TestTypedGraph(t *testing.T) {
+	g := NewTypedGraph[city, road]()
+	for _, name := range []string{"A", "B", "C"} {
+		g.AddVertex(city{name})
+	}
+	g.AddWeightedEdge(0, 1, 10, road{10})
+	g.AddWeightedEdge(1, 2, 20, road{20})
+	sp, err := Dijkstra(*g.Untyped(), 0, nil)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	path, err := sp.PathTo(2)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	km := 0
+	names := ""
+	err = g.TraversePath(path, func(vi, vj *TypedVertex[city], e *TypedEdge[road]) {
+		if names == "" {
+			names = vi.Data.name
+		}
+		names += vj.Data.name
+		km += e.Data.km
+	})
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if names != "ABC" || km != 30 {
+		t.Fatalf("g.TraversePath(): expected ABC and 30km, got %s and %dkm.", names, km)
+	}
+	other := NewTypedGraph[city, road]()
+	for _, name := range []string{"X", "Y", "Z"} {
+		other.AddVertex(city{name})
+	}
+	other.AddEdge(0, 1, road{1})
+	other.AddEdge(1, 2, road{2})
+	if err := other.TraversePath(path, func(vi, vj *TypedVertex[city], e *TypedEdge[road]) {}); err != ErrOtherGraph {
+		t.Fatalf("other.TraversePath(): expected ErrOtherGraph, got %v.", err)
+	}
+	g.Untyped().AddVertex("D")
+	if _, err := g.GetVertex(3); err == nil {
+		t.Fatalf("g.GetVertex(3): expected an error for a vertex holding a string")
+	}
+}
@@ -1,7 +1,86 @@
 package graph
 
-type vertex struct {
-	id   int
-	out  []out
-	Data interface{}
+// a vertex of a graph
+// vertices are created by Graph.AddVertex and retrieved by Graph.GetVertex
+type Vertex struct {
+	id  int
+	out []Adjacency
+	// edges entering this vertex, only used by directed graphs
+	in       []Adjacency
+	directed bool
+	removed  bool
+	Data     interface{}
+}
+
+// returns the id of this vertex
+func (v *Vertex) ID() int {
+	return v.id
+}
+
+// returns the number of edges incident to this vertex
+// self-loops are counted twice
+func (v *Vertex) Degree() int {
+	if v.directed {
+		return len(v.in) + len(v.out)
+	}
+	degree := len(v.out)
+	for _, o := range v.out {
+		if o.Endpoint == v.id {
+			degree++
+		}
+	}
+	return degree
+}
+
+// returns the number of edges entering this vertex
+// for undirected graphs this is the same as Degree
+func (v *Vertex) InDegree() int {
+	if v.directed {
+		return len(v.in)
+	}
+	return v.Degree()
+}
+
+// returns the number of edges leaving this vertex
+// for undirected graphs this is the same as Degree
+func (v *Vertex) OutDegree() int {
+	if v.directed {
+		return len(v.out)
+	}
+	return v.Degree()
+}
+
+// returns the ids of the vertexes adjacent to this vertex,
+// one entry per incident edge
+// for directed graphs only the vertexes reached by edges leaving this vertex are returned
+func (v *Vertex) Neighbors() []int {
+	neighbors := make([]int, len(v.out))
+	for i, o := range v.out {
+		neighbors[i] = o.Endpoint
+	}
+	return neighbors
+}
+
+// returns the ids of the vertexes with an edge entering this vertex,
+// one entry per edge
+// for undirected graphs this is the same as Neighbors
+func (v *Vertex) InNeighbors() []int {
+	if !v.directed {
+		return v.Neighbors()
+	}
+	neighbors := make([]int, len(v.in))
+	for i, o := range v.in {
+		neighbors[i] = o.Endpoint
+	}
+	return neighbors
+}
+
+// returns the ids of the edges incident to this vertex
+// for directed graphs only the edges leaving this vertex are returned
+func (v *Vertex) Edges() []int {
+	edges := make([]int, len(v.out))
+	for i, o := range v.out {
+		edges[i] = o.Edge
+	}
+	return edges
 }
@@ -0,0 +1,56 @@
+package graph
+
+// a WeightFunc returns the cost of traversing an edge
+// algorithms that accept a WeightFunc use EdgeWeight when nil is given
+type WeightFunc func(e *Edge) float64
+
+// returns the weight stored in the edge e
+func EdgeWeight(e *Edge) float64 {
+	return e.weight
+}
+
+// returns the weight of this edge
+// edges created by AddEdge have weight 1
+func (e *Edge) Weight() float64 {
+	return e.weight
+}
+
+// sets the weight of the edge E[edgeIndex]
+// or returns an error if the edgeIndex is out of bounds
+func (g *Graph) SetWeight(edgeIndex int, weight float64) error {
+	if err := g.testEdge(edgeIndex); err != nil {
+		return err
+	}
+	g.edges[edgeIndex].weight = weight
+	return nil
+}
+
+// returns w or EdgeWeight if w is nil
+func weightOrDefault(w WeightFunc) WeightFunc {
+	if w == nil {
+		return EdgeWeight
+	}
+	return w
+}
+
+// returns the sum of the weights of the edges in this path
+func (p Path) Weight() (float64, error) {
+	return p.WeightBy(EdgeWeight)
+}
+
+// returns the sum of the costs of the edges in this path as given by w
+func (p Path) WeightBy(w WeightFunc) (float64, error) {
+	if err := p.Validate(); err != nil {
+		return 0, err
+	}
+	w = weightOrDefault(w)
+	total := 0.0
+	for _, o := range p.out {
+		e, err := getEdge(p.graph, o.Edge)
+		if err != nil {
+			return 0, stale(err)
+		}
+		total += w(e)
+	}
+	return total, nil
+}